@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	_ "embed"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"io"
@@ -10,6 +11,7 @@ import (
 	"os"
 
 	"github.com/famasya/gdocs-cli/internal/auth"
+	"github.com/famasya/gdocs-cli/internal/document"
 	"github.com/famasya/gdocs-cli/internal/gdocs"
 	"github.com/famasya/gdocs-cli/internal/markdown"
 )
@@ -25,6 +27,31 @@ func main() {
 	cleanFlag := flag.Bool("clean", false, "Clean output (suppress all logs, only output markdown)")
 	commentsFlag := flag.Bool("comments", false, "Include document comments in the markdown output")
 	instructionFlag := flag.Bool("instruction", false, "Print integration instructions for AI coding agents")
+	imagesFlag := flag.String("images", string(markdown.ImagesRemote), "How to export images: remote (link to Google's temporary URL), files (download to --assets-dir), inline (data URIs)")
+	assetsDirFlag := flag.String("assets-dir", "assets", "Directory to write images to when --images=files")
+	codeLanguageFlag := flag.String("code-language", "", "Language hint for fenced code blocks detected from monospace text (e.g. go)")
+	flavorFlag := flag.String("flavor", string(markdown.FlavorGFM), "Markdown flavor: gfm, commonmark (HTML for tables, strikethrough, task lists and footnotes), obsidian (wikilinks, ==highlight==), pandoc (grid tables, {#id} headings)")
+	rawTextFlag := flag.Bool("raw-text", false, "Write document text verbatim without escaping markdown characters")
+	richInlineFlag := flag.Bool("rich-inline", false, "Render underline, superscript, subscript, highlight and text color as inline HTML")
+	plainMentionsFlag := flag.Bool("plain-mentions", false, "Render @-mentioned people as plain names instead of mailto links")
+	pageBreaksFlag := flag.String("page-breaks", string(markdown.BreakRule), "How to render page breaks: rule (---), html, none")
+	horizontalRulesFlag := flag.String("horizontal-rules", string(markdown.BreakRule), "How to render horizontal rules: rule (---), html, none")
+	tocFlag := flag.String("toc", string(markdown.TOCGenerate), "How to render a table of contents: generate (links to headings), drop")
+	headingAnchorsFlag := flag.String("heading-anchors", string(markdown.AnchorsGitHub), "How to write heading anchors: github (derived from the heading text), explicit ({#id} attributes)")
+	linkMapFlag := flag.String("link-map", "", "Path to a JSON file mapping document IDs or URLs to markdown paths; links to these documents become relative links")
+	blockquotesFlag := flag.String("blockquotes", "", "Comma-separated rules for paragraphs rendered as blockquotes: indent, border, shading, font=NAME (e.g. indent,font=Georgia)")
+	headingLevelsFlag := flag.String("heading-levels", "", "Comma-separated markdown heading levels for Docs styles (e.g. TITLE=1,HEADING_1=2)")
+	headingShiftFlag := flag.Int("heading-shift", 0, "Demote all headings by this many levels")
+	dropTitleFlag := flag.Bool("drop-title", false, "Omit the title paragraph (the title stays in the frontmatter)")
+	subtitleEmphasisFlag := flag.Bool("subtitle-emphasis", false, "Render the subtitle as emphasized text instead of a heading")
+	numberHeadingsFlag := flag.Bool("number-headings", false, "Number headings hierarchically (1, 1.1, 1.1.1)")
+	suggestionsFlag := flag.String("suggestions", "", "How to show suggested edits: inline (as CriticMarkup), accept-all, reject-all (default: as shown to you)")
+	headersFootersFlag := flag.String("headers-footers", string(markdown.HeaderFooterNone), "Render page headers and footers: none, sections (delimited blocks around the body), frontmatter (YAML fields)")
+	formatFlag := flag.String("format", "markdown", "Output format: markdown, html, asciidoc, rst, text (compact plain text for LLM prompts), json-ast (the document tree as JSON)")
+	htmlFragmentFlag := flag.Bool("html-fragment", false, "With --format=html, write only the body content instead of a standalone page")
+	chunkFlag := flag.Bool("chunk", false, "Write the markdown as JSONL chunks split at headings, for retrieval pipelines")
+	chunkSizeFlag := flag.Int("chunk-size", 4000, "With --chunk, the maximum chunk size in characters (0 splits at headings only)")
+	textLinksFlag := flag.String("text-links", string(document.TextLinksEnd), "With --format=text, how to write link URLs: none, inline (after the link text), end (numbered list at the end)")
 	flag.Parse()
 
 	// Handle instruction mode - print instructions and exit
@@ -66,16 +93,92 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Validate conversion options
+	opts := markdown.Options{
+		Images:           markdown.ImageMode(*imagesFlag),
+		AssetsDir:        *assetsDirFlag,
+		HeadersFooters:   markdown.HeaderFooterMode(*headersFootersFlag),
+		CodeLanguage:     *codeLanguageFlag,
+		Flavor:           markdown.Flavor(*flavorFlag),
+		RawText:          *rawTextFlag,
+		RichInline:       *richInlineFlag,
+		PlainMentions:    *plainMentionsFlag,
+		PageBreaks:       markdown.BreakMode(*pageBreaksFlag),
+		HorizontalRules:  markdown.BreakMode(*horizontalRulesFlag),
+		TableOfContents:  markdown.TOCMode(*tocFlag),
+		HeadingAnchors:   markdown.AnchorMode(*headingAnchorsFlag),
+		HeadingShift:     *headingShiftFlag,
+		DropTitle:        *dropTitleFlag,
+		SubtitleEmphasis: *subtitleEmphasisFlag,
+		NumberHeadings:   *numberHeadingsFlag,
+	}
+	if err := validateOptions(opts); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	blockquotes, err := markdown.ParseBlockquoteRules(*blockquotesFlag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	opts.Blockquotes = blockquotes
+
+	headingLevels, err := markdown.ParseHeadingLevels(*headingLevelsFlag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	opts.HeadingLevels = headingLevels
+
+	if *linkMapFlag != "" {
+		paths, err := loadLinkMap(*linkMapFlag)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		opts.DocumentPaths = paths
+	}
+
+	viewMode, err := gdocs.ParseSuggestionsMode(*suggestionsFlag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	opts.CriticMarkup = *suggestionsFlag == gdocs.SuggestionsInline
+
+	renderer, err := newRenderer(*formatFlag, opts, *htmlFragmentFlag, document.TextLinks(*textLinksFlag))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Chunks are split from the markdown output; -1 disables chunking
+	chunkSize := -1
+	if *chunkFlag {
+		if *formatFlag != "markdown" {
+			fmt.Fprintf(os.Stderr, "Error: --chunk requires --format=markdown\n")
+			os.Exit(1)
+		}
+		if *chunkSizeFlag < 0 {
+			fmt.Fprintf(os.Stderr, "Error: invalid --chunk-size value %d (expected 0 or more)\n", *chunkSizeFlag)
+			os.Exit(1)
+		}
+		chunkSize = *chunkSizeFlag
+	}
+
 	// Run the main logic
-	if err := run(*urlFlag, configPath, *commentsFlag); err != nil {
+	if err := run(*urlFlag, configPath, *commentsFlag, viewMode, opts, renderer, chunkSize); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 }
 
 // run executes the main logic of the CLI.
-// It handles authentication, document fetching, and markdown conversion.
-func run(docURL, credPath string, includeComments bool) error {
+// It handles authentication, document fetching, and conversion to the
+// renderer's output format, or to JSONL chunks of at most chunkSize
+// characters if chunkSize is not negative.
+func run(docURL, credPath string, includeComments bool, viewMode string, opts markdown.Options, renderer document.Renderer, chunkSize int) error {
 	ctx := context.Background()
 
 	// Extract document ID from URL
@@ -107,7 +210,7 @@ func run(docURL, credPath string, includeComments bool) error {
 
 	// Fetch document
 	log.Printf("Fetching document %s...", docID)
-	doc, err := client.FetchDocument(docID)
+	doc, err := client.FetchDocument(docID, viewMode)
 	if err != nil {
 		return fmt.Errorf("failed to fetch document: %w", err)
 	}
@@ -144,17 +247,132 @@ func run(docURL, credPath string, includeComments bool) error {
 		converter.SetComments(comments)
 	}
 
-	markdownOutput, err := converter.Convert()
+	// Download images through the authenticated client
+	opts.FetchImage = func(uri string) ([]byte, string, error) {
+		return gdocs.FetchImage(ctx, httpClient, uri)
+	}
+	converter.SetOptions(opts)
+
+	if chunkSize >= 0 {
+		chunks, err := converter.Chunks(chunkSize)
+		if err != nil {
+			return fmt.Errorf("conversion failed: %w", err)
+		}
+		log.Printf("Split into %d chunk(s)", len(chunks))
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetEscapeHTML(false)
+		for _, chunk := range chunks {
+			if err := encoder.Encode(chunk); err != nil {
+				return fmt.Errorf("failed to write chunk: %w", err)
+			}
+		}
+		return nil
+	}
+
+	output, err := converter.Render(renderer)
 	if err != nil {
 		return fmt.Errorf("conversion failed: %w", err)
 	}
 
 	// Print to stdout
-	fmt.Print(markdownOutput)
+	fmt.Print(output)
+
+	// Report the prompt size of plain text on stderr, even in clean mode,
+	// so it doesn't mix with the output
+	if _, ok := renderer.(document.TextRenderer); ok {
+		fmt.Fprintf(os.Stderr, "Estimated tokens: %d\n", document.EstimateTokens(output))
+	}
 
 	return nil
 }
 
+// validateOptions checks the conversion options set from flags.
+func validateOptions(opts markdown.Options) error {
+	switch opts.Images {
+	case markdown.ImagesRemote, markdown.ImagesFiles, markdown.ImagesInline:
+	default:
+		return fmt.Errorf("invalid --images value %q (expected remote, files or inline)", opts.Images)
+	}
+	switch opts.HeadersFooters {
+	case markdown.HeaderFooterNone, markdown.HeaderFooterSections, markdown.HeaderFooterFrontmatter:
+	default:
+		return fmt.Errorf("invalid --headers-footers value %q (expected none, sections or frontmatter)", opts.HeadersFooters)
+	}
+	for flagName, mode := range map[string]markdown.BreakMode{"page-breaks": opts.PageBreaks, "horizontal-rules": opts.HorizontalRules} {
+		switch mode {
+		case markdown.BreakRule, markdown.BreakHTML, markdown.BreakNone:
+		default:
+			return fmt.Errorf("invalid --%s value %q (expected rule, html or none)", flagName, mode)
+		}
+	}
+	switch opts.TableOfContents {
+	case markdown.TOCGenerate, markdown.TOCDrop:
+	default:
+		return fmt.Errorf("invalid --toc value %q (expected generate or drop)", opts.TableOfContents)
+	}
+	switch opts.Flavor {
+	case markdown.FlavorGFM, markdown.FlavorCommonMark, markdown.FlavorObsidian, markdown.FlavorPandoc:
+	default:
+		return fmt.Errorf("invalid --flavor value %q (expected gfm, commonmark, obsidian or pandoc)", opts.Flavor)
+	}
+	switch opts.HeadingAnchors {
+	case markdown.AnchorsGitHub, markdown.AnchorsExplicit:
+	default:
+		return fmt.Errorf("invalid --heading-anchors value %q (expected github or explicit)", opts.HeadingAnchors)
+	}
+	if opts.HeadingShift < 0 {
+		return fmt.Errorf("invalid --heading-shift value %d (expected 0 or more)", opts.HeadingShift)
+	}
+	return nil
+}
+
+// newRenderer returns the renderer for an output format.
+func newRenderer(format string, opts markdown.Options, htmlFragment bool, textLinks document.TextLinks) (document.Renderer, error) {
+	switch format {
+	case "markdown":
+		return markdown.NewRenderer(opts), nil
+	case "html":
+		return document.HTMLRenderer{Fragment: htmlFragment, Suggestions: opts.CriticMarkup}, nil
+	case "asciidoc":
+		return document.AsciiDocRenderer{}, nil
+	case "rst":
+		return document.RSTRenderer{}, nil
+	case "text":
+		switch textLinks {
+		case document.TextLinksNone, document.TextLinksInline, document.TextLinksEnd:
+		default:
+			return nil, fmt.Errorf("invalid --text-links value %q (expected none, inline or end)", textLinks)
+		}
+		return document.TextRenderer{Links: textLinks}, nil
+	case "json-ast":
+		return document.JSONRenderer{}, nil
+	}
+	return nil, fmt.Errorf("invalid --format value %q (expected markdown, html, asciidoc, rst, text or json-ast)", format)
+}
+
+// loadLinkMap reads a JSON object mapping document IDs or URLs to the
+// paths of their markdown files, keyed by document ID.
+func loadLinkMap(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read link map: %w", err)
+	}
+
+	var entries map[string]string
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse link map: %w", err)
+	}
+
+	paths := make(map[string]string, len(entries))
+	for key, target := range entries {
+		if docID, err := gdocs.ExtractDocumentID(key); err == nil {
+			key = docID
+		}
+		paths[key] = target
+	}
+	return paths, nil
+}
+
 // initAuth initializes OAuth authentication and saves the token.
 func initAuth(credPath string) error {
 	ctx := context.Background()
@@ -35,6 +35,31 @@ func TestCLIHelp(t *testing.T) {
 		"-init",
 		"-clean",
 		"-instruction",
+		"-images",
+		"-assets-dir",
+		"-headers-footers",
+		"-code-language",
+		"-raw-text",
+		"-rich-inline",
+		"-suggestions",
+		"-plain-mentions",
+		"-page-breaks",
+		"-horizontal-rules",
+		"-toc",
+		"-heading-anchors",
+		"-link-map",
+		"-blockquotes",
+		"-heading-levels",
+		"-heading-shift",
+		"-drop-title",
+		"-subtitle-emphasis",
+		"-number-headings",
+		"-format",
+		"-flavor",
+		"-html-fragment",
+		"-text-links",
+		"-chunk",
+		"-chunk-size",
 		"Google Docs URL",
 		"OAuth credentials JSON file",
 		"integration instructions",
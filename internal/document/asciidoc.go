@@ -0,0 +1,494 @@
+package document
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// AsciiDocRenderer renders the document tree as AsciiDoc, as read by
+// Asciidoctor and Antora.
+type AsciiDocRenderer struct{}
+
+// Render implements Renderer.
+func (AsciiDocRenderer) Render(doc *Document) (string, error) {
+	w := &asciidocWriter{title: doc.Metadata.Title, footnotes: make(map[int][]Block), seen: make(map[int]bool)}
+	for _, footnote := range doc.Footnotes {
+		w.footnotes[footnote.Number] = footnote.Blocks
+	}
+
+	var b strings.Builder
+	b.WriteString(asciidocHeader(doc.Metadata))
+
+	var parts []string
+	for _, section := range doc.Headers {
+		parts = append(parts, w.section(section))
+	}
+	if body := w.blocks(doc.Blocks, 0); body != "" {
+		parts = append(parts, body)
+	}
+	for _, section := range doc.Footers {
+		parts = append(parts, w.section(section))
+	}
+	if len(doc.Comments) > 0 {
+		parts = append(parts, w.comments(doc.Comments))
+	}
+	b.WriteString(strings.Join(parts, "\n"))
+
+	return b.String(), nil
+}
+
+// asciidocHeader returns the document header: the title and the metadata
+// as attributes, named like the frontmatter fields.
+func asciidocHeader(meta Metadata) string {
+	var b strings.Builder
+	if meta.Title != "" {
+		b.WriteString("= " + asciidocEscape(strings.ReplaceAll(meta.Title, "\n", " ")) + "\n")
+	}
+	attr := func(name, value string) {
+		if value != "" {
+			b.WriteString(":" + name + ": " + strings.ReplaceAll(value, "\n", " ") + "\n")
+		}
+	}
+	attr("author", meta.Author)
+	if !meta.CreatedDate.IsZero() {
+		attr("created", meta.CreatedDate.Format(time.RFC3339))
+	}
+	if !meta.ModifiedDate.IsZero() {
+		attr("revdate", meta.ModifiedDate.Format("2006-01-02"))
+	}
+	attr("tab", meta.Tab)
+	attr("mentions", mentionList(meta.Mentions))
+	attr("header", meta.Header)
+	attr("header_first_page", meta.HeaderFirstPage)
+	attr("header_even_page", meta.HeaderEvenPage)
+	attr("footer", meta.Footer)
+	attr("footer_first_page", meta.FooterFirstPage)
+	attr("footer_even_page", meta.FooterEvenPage)
+	if b.Len() > 0 {
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// asciidocWriter holds the state of rendering one document.
+type asciidocWriter struct {
+	// title is the document title, which a TITLE heading is not repeated
+	// after
+	title string
+	// footnotes holds footnote content by number, written inline at the
+	// first reference
+	footnotes map[int][]Block
+	seen      map[int]bool
+	// tableDepth is the number of tables around the block being rendered
+	tableDepth int
+}
+
+// section renders a page header or footer as an open block with a role
+// naming its variant, e.g. header-first-page.
+func (w *asciidocWriter) section(section Section) string {
+	return "[." + strings.ReplaceAll(section.Label, " ", "-") + "]\n--\n" + w.blocks(section.Blocks, 0) + "--\n"
+}
+
+// blocks renders blocks separated by blank lines. depth is the nesting
+// depth of blockquotes.
+func (w *asciidocWriter) blocks(blocks []Block, depth int) string {
+	var parts []string
+	for i, block := range blocks {
+		text := w.block(block, depth)
+		if text == "" {
+			continue
+		}
+		// Adjacent lists would otherwise be joined into one
+		if block.List != nil && i > 0 && blocks[i-1].List != nil {
+			text = "//-\n\n" + text
+		}
+		parts = append(parts, text)
+	}
+	return strings.Join(parts, "\n")
+}
+
+// block renders a single block, ending with a newline.
+func (w *asciidocWriter) block(block Block, depth int) string {
+	switch {
+	case block.Paragraph != nil:
+		return w.paragraph(block.Paragraph)
+	case block.Heading != nil:
+		return w.heading(block.Heading)
+	case block.List != nil:
+		return w.list(block.List, 1)
+	case block.Table != nil:
+		return w.table(block.Table)
+	case block.CodeBlock != nil:
+		return asciidocCode(block.CodeBlock)
+	case block.Blockquote != nil:
+		delim := strings.Repeat("_", 4+depth)
+		return delim + "\n" + w.blocks(block.Blockquote.Blocks, depth+1) + delim + "\n"
+	case block.Break != nil:
+		if block.Break.Kind == PageBreak {
+			return "<<<\n"
+		}
+		return "'''\n"
+	case block.TableOfContents != nil:
+		return asciidocTableOfContents(block.TableOfContents)
+	}
+	return ""
+}
+
+// paragraph renders a paragraph. A paragraph holding only an image is
+// rendered as a block image.
+func (w *asciidocWriter) paragraph(paragraph *Paragraph) string {
+	if len(paragraph.Inlines) == 1 && paragraph.Inlines[0].Image != nil {
+		return "image::" + asciidocImageTarget(paragraph.Inlines[0].Image) + "\n"
+	}
+	text := w.inlines(paragraph.Inlines)
+	if text == "" {
+		return ""
+	}
+	return asciidocLines(text) + "\n"
+}
+
+// heading renders a heading with its anchor. Section levels start at ==,
+// since = is the document title.
+func (w *asciidocWriter) heading(heading *Heading) string {
+	text := w.inlines(heading.Inlines)
+	if text == "" || repeatsTitle(heading, w.title) {
+		return ""
+	}
+	text = strings.ReplaceAll(text, " +\n", " ")
+	if heading.Number != "" {
+		text = heading.Number + " " + text
+	}
+	var b strings.Builder
+	if heading.Anchor != "" {
+		b.WriteString("[[" + heading.Anchor + "]]\n")
+	}
+	b.WriteString(strings.Repeat("=", min(heading.Level+1, 6)) + " " + text + "\n")
+	return b.String()
+}
+
+// list renders a list at a nesting depth. Nested lists follow their item
+// directly; other blocks are attached to the item with a + line.
+func (w *asciidocWriter) list(list *List, depth int) string {
+	marker := strings.Repeat("*", depth)
+	if list.Ordered {
+		marker = strings.Repeat(".", depth)
+	}
+
+	var b strings.Builder
+	if list.Ordered && len(list.Items) > 0 && list.Items[0].Number > 1 {
+		fmt.Fprintf(&b, "[start=%d]\n", list.Items[0].Number)
+	}
+	for _, item := range list.Items {
+		blocks := item.Blocks
+		text := "{empty}"
+		if len(blocks) > 0 && blocks[0].Paragraph != nil {
+			if t := w.inlines(blocks[0].Paragraph.Inlines); t != "" {
+				text = t
+			}
+			blocks = blocks[1:]
+		}
+		if item.Checked != nil && *item.Checked {
+			text = "[x] " + text
+		} else if item.Checked != nil {
+			text = "[ ] " + text
+		}
+		b.WriteString(marker + " " + text + "\n")
+
+		for _, block := range blocks {
+			if block.List != nil {
+				b.WriteString(w.list(block.List, depth+1))
+			} else if text := w.block(block, 0); text != "" {
+				b.WriteString("+\n" + text)
+			}
+		}
+	}
+	return b.String()
+}
+
+// table renders a table with its first row as the header and merged cells
+// as span operators. Cells with more than a paragraph use the AsciiDoc
+// cell style. Tables nested in a cell use ! as their separator; AsciiDoc
+// cannot nest them further, so deeper tables are written as their cells'
+// blocks.
+func (w *asciidocWriter) table(table *Table) string {
+	if w.tableDepth > 1 {
+		var parts []string
+		for _, row := range table.Rows {
+			for _, cell := range row.Cells {
+				if text := w.blocks(cell.Blocks, 0); text != "" {
+					parts = append(parts, text)
+				}
+			}
+		}
+		return strings.Join(parts, "\n")
+	}
+	sep := "|"
+	if w.tableDepth > 0 {
+		sep = "!"
+	}
+	w.tableDepth++
+	defer func() { w.tableDepth-- }()
+
+	columns := 0
+	if len(table.Rows) > 0 {
+		for _, cell := range table.Rows[0].Cells {
+			columns += max(cell.ColSpan, 1)
+		}
+	}
+
+	// Separators in cell content are escaped. Nested tables use another
+	// separator, so only their text is affected, and it is unescaped
+	// when the cell is parsed
+	escape := func(s string) string { return strings.ReplaceAll(s, sep, `\`+sep) }
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "[%%header,cols=\"%d*\"]\n%s===\n", columns, sep)
+	for i, row := range table.Rows {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		for _, cell := range row.Cells {
+			spec := ""
+			if cell.ColSpan > 1 {
+				spec += fmt.Sprint(cell.ColSpan)
+			}
+			if cell.RowSpan > 1 {
+				spec += fmt.Sprintf(".%d", cell.RowSpan)
+			}
+			if spec != "" {
+				spec += "+"
+			}
+
+			if len(cell.Blocks) == 1 && cell.Blocks[0].Paragraph != nil {
+				b.WriteString(spec + sep + escape(w.inlines(cell.Blocks[0].Paragraph.Inlines)) + "\n")
+			} else if content := w.blocks(cell.Blocks, 0); content != "" {
+				b.WriteString(spec + "a" + sep + escape(content))
+			} else {
+				b.WriteString(spec + sep + "\n")
+			}
+		}
+	}
+	b.WriteString(sep + "===\n")
+	return b.String()
+}
+
+// asciidocCode renders a listing block, with a delimiter no line of the
+// code can close.
+func asciidocCode(code *CodeBlock) string {
+	delim := "----"
+	for strings.Contains("\n"+code.Code+"\n", "\n"+delim+"\n") {
+		delim += "-"
+	}
+	var b strings.Builder
+	if code.Language != "" {
+		b.WriteString("[source," + code.Language + "]\n")
+	}
+	b.WriteString(delim + "\n" + code.Code + "\n" + delim + "\n")
+	return b.String()
+}
+
+// asciidocTableOfContents renders a table of contents as a nested list of
+// cross references to the document's headings.
+func asciidocTableOfContents(toc *TableOfContents) string {
+	minLevel := 0
+	for _, entry := range toc.Entries {
+		if minLevel == 0 || entry.Level < minLevel {
+			minLevel = entry.Level
+		}
+	}
+	var b strings.Builder
+	for _, entry := range toc.Entries {
+		marker := strings.Repeat("*", entry.Level-minLevel+1)
+		b.WriteString(marker + " <<" + entry.Anchor + "," + asciidocEscape(entry.Text) + ">>\n")
+	}
+	return b.String()
+}
+
+// comments renders comments as a section with their quoted text and
+// replies.
+func (w *asciidocWriter) comments(comments []Comment) string {
+	var parts []string
+	parts = append(parts, "== Comments\n")
+	for _, c := range comments {
+		var b strings.Builder
+		if c.QuotedText != "" {
+			b.WriteString("____\n" + asciidocEscape(c.QuotedText) + "\n____\n\n")
+		}
+		b.WriteString(asciidocByline(c.Author, c.CreatedTime))
+		if c.Resolved {
+			b.WriteString(" ✓ resolved")
+		}
+		b.WriteString(": " + asciidocEscape(c.Content) + "\n")
+		for _, r := range c.Replies {
+			b.WriteString("\n* " + asciidocByline(r.Author, r.CreatedTime) + ": " + asciidocEscape(r.Content) + "\n")
+		}
+		parts = append(parts, b.String())
+	}
+	return strings.Join(parts, "\n")
+}
+
+// asciidocByline returns the author of a comment or reply in bold, with
+// the date it was written.
+func asciidocByline(author, created string) string {
+	if author == "" {
+		author = "Unknown"
+	}
+	byline := "*" + asciidocEscape(author) + "*"
+	if t, err := time.Parse(time.RFC3339, created); err == nil {
+		byline += " (" + t.Format("2006-01-02") + ")"
+	}
+	return byline
+}
+
+// inlines renders inlines as AsciiDoc. Line breaks are written as " +"
+// at the end of the line.
+func (w *asciidocWriter) inlines(inlines []Inline) string {
+	var b strings.Builder
+	for _, inline := range MergeText(inlines) {
+		switch {
+		case inline.Text != nil:
+			b.WriteString(asciidocText(inline.Text))
+		case inline.LineBreak:
+			b.WriteString(" +\n")
+		case inline.Image != nil:
+			b.WriteString("image:" + asciidocImageTarget(inline.Image))
+		case inline.FootnoteRef != nil:
+			b.WriteString(w.footnote(inline.FootnoteRef.Number))
+		case inline.Mention != nil:
+			m := inline.Mention
+			name := m.Name
+			if name == "" {
+				name = m.Email
+			}
+			if m.Email == "" {
+				b.WriteString(asciidocEscape(name))
+			} else {
+				b.WriteString("mailto:" + m.Email + "[" + asciidocEscape(name) + "]")
+			}
+		case inline.Date != nil:
+			b.WriteString(asciidocEscape(inline.Date.Value))
+		case inline.Math != nil:
+			if inline.Math.TeX == "" {
+				b.WriteString("__{startsb}equation{endsb}__")
+			} else {
+				b.WriteString("latexmath:[" + strings.ReplaceAll(inline.Math.TeX, "]", `\]`) + "]")
+			}
+		}
+	}
+	return b.String()
+}
+
+// footnote renders a footnote with its content at the first reference and
+// a reference to it afterwards.
+func (w *asciidocWriter) footnote(number int) string {
+	id := fmt.Sprintf("fn%d", number)
+	if w.seen[number] {
+		return "footnote:" + id + "[]"
+	}
+	w.seen[number] = true
+
+	var paragraphs []string
+	for _, block := range w.footnotes[number] {
+		if block.Paragraph != nil {
+			if text := w.inlines(block.Paragraph.Inlines); text != "" {
+				paragraphs = append(paragraphs, text)
+			}
+		}
+	}
+	// Literal brackets are already escaped, and the brackets of macros
+	// in the text must stay as they are
+	text := strings.Join(paragraphs, " ")
+	text = strings.ReplaceAll(text, " +\n", " ")
+	return "footnote:" + id + "[" + text + "]"
+}
+
+// asciidocText renders a run of text with its style. Unconstrained
+// markup is used so formatting also works inside words.
+func asciidocText(t *Text) string {
+	core := strings.TrimSpace(t.Value)
+	if core == "" {
+		return asciidocEscape(t.Value)
+	}
+	start := strings.Index(t.Value, core)
+	leading, trailing := t.Value[:start], t.Value[start+len(core):]
+
+	s := t.TextStyle
+	text := asciidocEscape(core)
+	if s.Code {
+		text = "``" + text + "``"
+	}
+	switch s.BaselineOffset {
+	case Superscript:
+		text = "^" + text + "^"
+	case Subscript:
+		text = "~" + text + "~"
+	}
+	if s.Underline {
+		text = "[.underline]##" + text + "##"
+	}
+	if s.Strikethrough {
+		text = "[.line-through]##" + text + "##"
+	}
+	if s.Background == HighlightColor {
+		text = "##" + text + "##"
+	}
+	if s.Italic {
+		text = "__" + text + "__"
+	}
+	if s.Bold {
+		text = "**" + text + "**"
+	}
+	if s.Link != "" {
+		if anchor, ok := strings.CutPrefix(s.Link, "#"); ok {
+			text = "<<" + anchor + "," + text + ">>"
+		} else {
+			text = "link:" + s.Link + "[" + strings.ReplaceAll(text, "]", `\]`) + "]"
+		}
+	}
+	return asciidocEscape(leading) + text + asciidocEscape(trailing)
+}
+
+// asciidocImageTarget renders the target and alt text of an image macro.
+func asciidocImageTarget(image *Image) string {
+	alt := image.Alt
+	if strings.ContainsAny(alt, `,="]`) {
+		alt = `"` + strings.ReplaceAll(alt, `"`, `\"`) + `"`
+	}
+	return image.Src + "[" + alt + "]"
+}
+
+// asciidocEscaper replaces characters that start inline markup with
+// attribute references.
+var asciidocEscaper = strings.NewReplacer(
+	"{", `\{`,
+	"*", "{asterisk}",
+	"`", "{backtick}",
+	"^", "{caret}",
+	"~", "{tilde}",
+	"+", "{plus}",
+	"[", "{startsb}",
+	"]", "{endsb}",
+)
+
+// asciidocEscape escapes literal text.
+func asciidocEscape(s string) string {
+	return asciidocEscaper.Replace(s)
+}
+
+// asciidocBlockStart matches line starts that AsciiDoc reads as block
+// syntax: titles, list markers, delimiters, comments and attributes.
+var asciidocBlockStart = regexp.MustCompile(`^([=.\-/:|<>']|\d+\. )`)
+
+// asciidocLines keeps literal text at the start of each line from being
+// read as block syntax.
+func asciidocLines(text string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if asciidocBlockStart.MatchString(line) {
+			lines[i] = "{empty}" + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,212 @@
+// Package document defines a format-independent tree of a converted Google
+// Docs document. The tree is built once from the Docs API response and
+// written out by a Renderer, so every output format shares one traversal.
+package document
+
+import (
+	"strings"
+	"time"
+)
+
+// Renderer writes a document tree in an output format.
+type Renderer interface {
+	Render(doc *Document) (string, error)
+}
+
+// Document is the root of the tree.
+type Document struct {
+	// DocumentID and TabID identify the converted document and tab in
+	// Google Docs.
+	DocumentID string   `json:"document_id,omitempty"`
+	TabID      string   `json:"tab_id,omitempty"`
+	Metadata   Metadata `json:"metadata"`
+	// Headers and Footers are only set when page headers and footers are
+	// rendered as sections.
+	Headers   []Section  `json:"headers,omitempty"`
+	Blocks    []Block    `json:"blocks"`
+	Footers   []Section  `json:"footers,omitempty"`
+	Footnotes []Footnote `json:"footnotes,omitempty"`
+	Comments  []Comment  `json:"comments,omitempty"`
+}
+
+// Metadata holds information about the document, written as frontmatter
+// by the markdown renderer.
+type Metadata struct {
+	Title        string    `yaml:"title" json:"title"`
+	Author       string    `yaml:"author,omitempty" json:"author,omitempty"`
+	CreatedDate  time.Time `yaml:"created,omitempty" json:"created,omitzero"`
+	ModifiedDate time.Time `yaml:"modified,omitempty" json:"modified,omitzero"`
+	Tab          string    `yaml:"tab,omitempty" json:"tab,omitempty"`
+	Mentions     []Mention `yaml:"mentions,omitempty" json:"mentions,omitempty"`
+
+	// Header and footer text, set when rendering them as metadata
+	Header          string `yaml:"header,omitempty" json:"header,omitempty"`
+	HeaderFirstPage string `yaml:"header_first_page,omitempty" json:"header_first_page,omitempty"`
+	HeaderEvenPage  string `yaml:"header_even_page,omitempty" json:"header_even_page,omitempty"`
+	Footer          string `yaml:"footer,omitempty" json:"footer,omitempty"`
+	FooterFirstPage string `yaml:"footer_first_page,omitempty" json:"footer_first_page,omitempty"`
+	FooterEvenPage  string `yaml:"footer_even_page,omitempty" json:"footer_even_page,omitempty"`
+}
+
+// Mention is a person mentioned in the document with a person chip.
+type Mention struct {
+	Name  string `yaml:"name,omitempty" json:"name,omitempty"`
+	Email string `yaml:"email,omitempty" json:"email,omitempty"`
+}
+
+// repeatsTitle reports whether a heading is the document's title
+// paragraph repeating the title in the metadata, which renderers that
+// write the title from the metadata leave out.
+func repeatsTitle(heading *Heading, title string) bool {
+	return heading.Style == "TITLE" && title != "" && oneLine(PlainText(heading.Inlines)) == oneLine(title)
+}
+
+// mentionList lists mentioned people as "Name <email>", separated by
+// commas.
+func mentionList(mentions []Mention) string {
+	var list []string
+	for _, m := range mentions {
+		switch {
+		case m.Name != "" && m.Email != "":
+			list = append(list, m.Name+" <"+m.Email+">")
+		case m.Name != "":
+			list = append(list, m.Name)
+		default:
+			list = append(list, m.Email)
+		}
+	}
+	return strings.Join(list, ", ")
+}
+
+// Section is a page header or footer.
+type Section struct {
+	Kind   string  `json:"kind"`  // header or footer
+	Label  string  `json:"label"` // e.g. "header first page"
+	Blocks []Block `json:"blocks"`
+}
+
+// Block is a block-level element. Exactly one field is set.
+type Block struct {
+	Paragraph       *Paragraph       `json:"paragraph,omitempty"`
+	Heading         *Heading         `json:"heading,omitempty"`
+	List            *List            `json:"list,omitempty"`
+	Table           *Table           `json:"table,omitempty"`
+	CodeBlock       *CodeBlock       `json:"code_block,omitempty"`
+	Blockquote      *Blockquote      `json:"blockquote,omitempty"`
+	Break           *Break           `json:"break,omitempty"`
+	TableOfContents *TableOfContents `json:"table_of_contents,omitempty"`
+}
+
+// Paragraph is a paragraph of text. An empty paragraph is a blank line.
+type Paragraph struct {
+	Inlines []Inline `json:"inlines"`
+}
+
+// Heading is a title, subtitle or heading.
+type Heading struct {
+	// Level is the output heading level, 1-6, after the converter's
+	// heading options are applied.
+	Level int `json:"level"`
+	// Style is the Docs paragraph style, e.g. TITLE or HEADING_2.
+	Style string `json:"style"`
+	// Number is the hierarchical number, if headings are numbered.
+	Number string `json:"number,omitempty"`
+	// Anchor is the slug that links to the heading point to.
+	Anchor string `json:"anchor,omitempty"`
+	// ID is the Docs heading ID, e.g. h.abc123.
+	ID      string   `json:"id,omitempty"`
+	Inlines []Inline `json:"inlines"`
+}
+
+// List is a bulleted, numbered or check list.
+type List struct {
+	Ordered bool       `json:"ordered,omitempty"`
+	Items   []ListItem `json:"items"`
+}
+
+// ListItem is an item of a list. Its first block is usually the item's
+// paragraph, followed by continuation paragraphs and nested lists. An item
+// without a paragraph only holds a nested list.
+type ListItem struct {
+	// Number is the item's number in an ordered list.
+	Number int64 `json:"number,omitempty"`
+	// Checked is set for check list items.
+	Checked *bool   `json:"checked,omitempty"`
+	Blocks  []Block `json:"blocks"`
+}
+
+// Table is a table. Cells covered by a merged cell are left out of their
+// rows, as in HTML.
+type Table struct {
+	Rows []TableRow `json:"rows"`
+}
+
+// TableRow is a row of a table.
+type TableRow struct {
+	Cells []TableCell `json:"cells"`
+}
+
+// TableCell is a cell of a table.
+type TableCell struct {
+	RowSpan int     `json:"row_span,omitempty"`
+	ColSpan int     `json:"col_span,omitempty"`
+	Blocks  []Block `json:"blocks"`
+}
+
+// CodeBlock is preformatted code.
+type CodeBlock struct {
+	Language string `json:"language,omitempty"`
+	Code     string `json:"code"`
+}
+
+// Blockquote is a quoted passage. Nested quotes are nested blockquotes.
+type Blockquote struct {
+	Blocks []Block `json:"blocks"`
+}
+
+// Kinds of breaks.
+const (
+	HorizontalRule = "horizontal_rule"
+	PageBreak      = "page_break"
+)
+
+// Break is a horizontal rule or page break.
+type Break struct {
+	Kind string `json:"kind"`
+}
+
+// TableOfContents lists the document's headings.
+type TableOfContents struct {
+	Entries []TOCEntry `json:"entries"`
+}
+
+// TOCEntry is a heading listed in a table of contents.
+type TOCEntry struct {
+	Level  int    `json:"level"` // Docs heading level, 1-6
+	Text   string `json:"text"`
+	Anchor string `json:"anchor"`
+}
+
+// Footnote is the content of a footnote, numbered in order of first
+// reference.
+type Footnote struct {
+	Number int     `json:"number"`
+	Blocks []Block `json:"blocks"`
+}
+
+// Comment is a comment on the document.
+type Comment struct {
+	Author      string  `json:"author,omitempty"`
+	Content     string  `json:"content"`
+	QuotedText  string  `json:"quoted_text,omitempty"`
+	CreatedTime string  `json:"created_time,omitempty"`
+	Resolved    bool    `json:"resolved,omitempty"`
+	Replies     []Reply `json:"replies,omitempty"`
+}
+
+// Reply is a reply to a comment.
+type Reply struct {
+	Author      string `json:"author,omitempty"`
+	Content     string `json:"content"`
+	CreatedTime string `json:"created_time,omitempty"`
+}
@@ -0,0 +1,197 @@
+package document
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+var update = flag.Bool("update", false, "update golden files in testdata")
+
+// sampleDocument returns a document exercising the blocks and inlines
+// every text format renders.
+func sampleDocument() *Document {
+	checked, unchecked := true, false
+	return &Document{
+		Metadata: Metadata{
+			Title:        "Release Plan",
+			Author:       "Ada Lovelace",
+			ModifiedDate: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+			Mentions:     []Mention{{Name: "Bob", Email: "bob@example.com"}},
+			Header:       "Internal",
+		},
+		Headers: []Section{{Kind: "header", Label: "header", Blocks: []Block{paragraph(text("Internal", TextStyle{}))}}},
+		Blocks: []Block{
+			{Heading: &Heading{Level: 1, Style: "TITLE", Inlines: []Inline{text("Release Plan", TextStyle{})}}},
+			{TableOfContents: &TableOfContents{Entries: []TOCEntry{
+				{Level: 1, Text: "Goals", Anchor: "goals"},
+				{Level: 2, Text: "Schedule", Anchor: "schedule"},
+			}}},
+			{Heading: &Heading{Level: 1, Style: "HEADING_1", Number: "1.", Anchor: "goals", Inlines: []Inline{text("Goals", TextStyle{})}}},
+			paragraph(
+				text("Ship ", TextStyle{}),
+				text("v2", TextStyle{Bold: true}),
+				text(" with ", TextStyle{}),
+				text("faster", TextStyle{Italic: true}),
+				text(" sync", TextStyle{}),
+				Inline{FootnoteRef: &FootnoteRef{Number: 1}},
+				text(". Run ", TextStyle{}),
+				text("make release", TextStyle{Code: true}),
+				text(" and read ", TextStyle{}),
+				text("the guide", TextStyle{Link: "https://example.com/guide"}),
+				text(" or ", TextStyle{}),
+				text("the schedule", TextStyle{Link: "#schedule"}),
+				text(".", TextStyle{}),
+			),
+			paragraph(
+				text("Owner: ", TextStyle{}),
+				Inline{Mention: &Mention{Name: "Bob", Email: "bob@example.com"}},
+				Inline{LineBreak: true},
+				text("Budget *not* final [draft]", TextStyle{}),
+			),
+			{List: &List{Items: []ListItem{
+				{Blocks: []Block{
+					paragraph(text("Backend", TextStyle{})),
+					{List: &List{Ordered: true, Items: []ListItem{
+						{Number: 1, Blocks: []Block{paragraph(text("Migrate", TextStyle{}))}},
+						{Number: 2, Blocks: []Block{paragraph(text("Deploy", TextStyle{}))}},
+					}}},
+				}},
+				{Checked: &checked, Blocks: []Block{paragraph(text("Design", TextStyle{}))}},
+				{Checked: &unchecked, Blocks: []Block{paragraph(text("Docs", TextStyle{}))}},
+			}}},
+			{Heading: &Heading{Level: 2, Style: "HEADING_2", Anchor: "schedule", Inlines: []Inline{text("Schedule", TextStyle{})}}},
+			{Table: &Table{Rows: []TableRow{
+				{Cells: []TableCell{
+					{Blocks: []Block{paragraph(text("Phase", TextStyle{}))}},
+					{Blocks: []Block{paragraph(text("Date", TextStyle{}))}},
+				}},
+				{Cells: []TableCell{
+					{Blocks: []Block{paragraph(text("Beta", TextStyle{}))}},
+					{Blocks: []Block{paragraph(text("June | July", TextStyle{}))}},
+				}},
+			}}},
+			{Table: &Table{Rows: []TableRow{
+				{Cells: []TableCell{
+					{ColSpan: 2, Blocks: []Block{paragraph(text("Team", TextStyle{}))}},
+				}},
+				{Cells: []TableCell{
+					{RowSpan: 2, Blocks: []Block{paragraph(text("Core", TextStyle{}))}},
+					{Blocks: []Block{paragraph(text("Ada", TextStyle{}))}},
+				}},
+				{Cells: []TableCell{
+					{Blocks: []Block{paragraph(text("Bob", TextStyle{}))}},
+				}},
+			}}},
+			{CodeBlock: &CodeBlock{Language: "sh", Code: "make release\n./deploy.sh"}},
+			{Blockquote: &Blockquote{Blocks: []Block{paragraph(text("Ship small, ship often.", TextStyle{}))}}},
+			{Break: &Break{Kind: HorizontalRule}},
+			paragraph(Inline{Image: &Image{Src: "images/image-1.png", Alt: "Timeline"}}),
+		},
+		Footnotes: []Footnote{{Number: 1, Blocks: []Block{paragraph(text("Measured on the staging cluster.", TextStyle{}))}}},
+		Comments: []Comment{{
+			Author:      "Bob",
+			Content:     "Is June realistic?",
+			QuotedText:  "June",
+			CreatedTime: "2024-05-02T08:00:00Z",
+			Replies:     []Reply{{Author: "Ada", Content: "Yes", CreatedTime: "2024-05-02T09:00:00Z"}},
+		}},
+	}
+}
+
+// skippedLevelsDocument returns a document whose headings skip levels
+// and start below the top level.
+func skippedLevelsDocument() *Document {
+	heading := func(level int, value string) Block {
+		return Block{Heading: &Heading{
+			Level:   level,
+			Style:   fmt.Sprintf("HEADING_%d", level),
+			Inlines: []Inline{text(value, TextStyle{})},
+		}}
+	}
+	return &Document{Blocks: []Block{
+		heading(2, "Summary"),
+		heading(1, "Design"),
+		heading(3, "Storage"),
+		paragraph(text("Rows are kept in one table.", TextStyle{})),
+		heading(2, "Sync"),
+		heading(4, "Conflicts"),
+		heading(1, "Rollout"),
+	}}
+}
+
+// nestedDocument returns a document with markup in a footnote and tables
+// nested in table cells.
+func nestedDocument() *Document {
+	cell := func(blocks ...Block) TableCell {
+		return TableCell{Blocks: blocks}
+	}
+	textCell := func(value string) TableCell {
+		return cell(paragraph(text(value, TextStyle{})))
+	}
+	innermost := Block{Table: &Table{Rows: []TableRow{{Cells: []TableCell{textCell("Staging"), textCell("Production")}}}}}
+	nested := Block{Table: &Table{Rows: []TableRow{
+		{Cells: []TableCell{textCell("Item"), textCell("Owner!")}},
+		{Cells: []TableCell{textCell("Retry | backoff"), cell(paragraph(text("Rollout", TextStyle{})), innermost)}},
+	}}}
+	return &Document{
+		Metadata: Metadata{Title: "Sync *v2* [draft]"},
+		Blocks: []Block{
+			{Heading: &Heading{Level: 1, Style: "TITLE", Inlines: []Inline{text("Sync *v2* [draft]", TextStyle{})}}},
+			paragraph(text("Sync is late", TextStyle{}), Inline{FootnoteRef: &FootnoteRef{Number: 1}}, text(".", TextStyle{})),
+			{Table: &Table{Rows: []TableRow{
+				{Cells: []TableCell{textCell("Area"), textCell("Notes")}},
+				{Cells: []TableCell{textCell("Sync"), cell(paragraph(text("Open items | risks", TextStyle{})), nested)}},
+			}}},
+		},
+		Footnotes: []Footnote{{Number: 1, Blocks: []Block{paragraph(
+			text("See ", TextStyle{}),
+			text("the [draft] guide", TextStyle{Link: "https://example.com/guide"}),
+			text(" or ask ", TextStyle{}),
+			Inline{Mention: &Mention{Name: "Bob", Email: "bob@example.com"}},
+			text(".", TextStyle{}),
+		)}}},
+	}
+}
+
+func TestGolden(t *testing.T) {
+	tests := []struct {
+		name     string
+		renderer Renderer
+		doc      func() *Document
+		file     string
+	}{
+		{name: "asciidoc", renderer: AsciiDocRenderer{}, doc: sampleDocument, file: "sample.adoc"},
+		{name: "asciidoc nested", renderer: AsciiDocRenderer{}, doc: nestedDocument, file: "nested.adoc"},
+		{name: "rst", renderer: RSTRenderer{}, doc: sampleDocument, file: "sample.rst"},
+		{name: "rst skipped levels", renderer: RSTRenderer{}, doc: skippedLevelsDocument, file: "skipped-levels.rst"},
+		{name: "text", renderer: TextRenderer{Links: TextLinksEnd}, doc: sampleDocument, file: "sample.txt"},
+		{name: "text skipped levels", renderer: TextRenderer{}, doc: skippedLevelsDocument, file: "skipped-levels.txt"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.renderer.Render(tt.doc())
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+
+			path := filepath.Join("testdata", tt.file)
+			if *update {
+				if err := os.WriteFile(path, []byte(got), 0644); err != nil {
+					t.Fatalf("failed to update golden file: %v", err)
+				}
+			}
+			want, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("failed to read golden file: %v", err)
+			}
+			if got != string(want) {
+				t.Errorf("Render() =\n%s\nwant\n%s", got, want)
+			}
+		})
+	}
+}
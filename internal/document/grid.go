@@ -0,0 +1,217 @@
+package document
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/width"
+)
+
+// placedCell is a table cell at its position on the table's grid.
+type placedCell struct {
+	row, col, rowSpan, colSpan int
+	cell                       TableCell
+}
+
+// placeCells places the cells of a table on a grid, skipping positions
+// covered by merged cells above, and returns them with the number of
+// columns. Positions of short rows are filled with empty cells.
+func placeCells(table *Table) ([]placedCell, int) {
+	rows := len(table.Rows)
+	var cells []placedCell
+	occupied := make([][]bool, rows)
+	columns := 0
+	for r, row := range table.Rows {
+		col := 0
+		for _, cell := range row.Cells {
+			for col < len(occupied[r]) && occupied[r][col] {
+				col++
+			}
+			c := placedCell{row: r, col: col, rowSpan: min(max(cell.RowSpan, 1), rows-r), colSpan: max(cell.ColSpan, 1), cell: cell}
+			for i := r; i < r+c.rowSpan; i++ {
+				for len(occupied[i]) < col+c.colSpan {
+					occupied[i] = append(occupied[i], false)
+				}
+				for j := col; j < col+c.colSpan; j++ {
+					occupied[i][j] = true
+				}
+			}
+			cells = append(cells, c)
+			col += c.colSpan
+			columns = max(columns, col)
+		}
+	}
+	for r := range occupied {
+		for col := 0; col < columns; col++ {
+			if col >= len(occupied[r]) || !occupied[r][col] {
+				cells = append(cells, placedCell{row: r, col: col, rowSpan: 1, colSpan: 1})
+			}
+		}
+	}
+	return cells, columns
+}
+
+// GridTable draws a table as a grid table, the table syntax shared by
+// reStructuredText and Pandoc markdown that allows merged cells and block
+// content. content renders the text of a cell, which may span several
+// lines. The first row is the header.
+func GridTable(table *Table, content func(TableCell) string) string {
+	type gridCell struct {
+		placedCell
+		lines []string
+	}
+
+	rows := len(table.Rows)
+	if rows == 0 {
+		return ""
+	}
+
+	placed, columns := placeCells(table)
+	cells := make([]gridCell, len(placed))
+	for i, p := range placed {
+		cells[i].placedCell = p
+		if text := strings.TrimRight(content(p.cell), "\n"); text != "" {
+			cells[i].lines = strings.Split(text, "\n")
+		}
+	}
+
+	// Size columns and rows to their cells. Merged cells that don't fit
+	// widen their last column or heighten their last row.
+	widths := make([]int, columns)
+	heights := make([]int, rows)
+	for i := range widths {
+		widths[i] = 1
+	}
+	for i := range heights {
+		heights[i] = 1
+	}
+	cellWidth := func(c gridCell) int {
+		width := 0
+		for _, line := range c.lines {
+			width = max(width, displayWidth(line))
+		}
+		return width
+	}
+	for _, c := range cells {
+		if c.colSpan == 1 {
+			widths[c.col] = max(widths[c.col], cellWidth(c))
+		}
+		if c.rowSpan == 1 {
+			heights[c.row] = max(heights[c.row], len(c.lines))
+		}
+	}
+	for _, c := range cells {
+		// Each column boundary inside a cell adds a border and padding
+		span := 3 * (c.colSpan - 1)
+		for j := c.col; j < c.col+c.colSpan; j++ {
+			span += widths[j]
+		}
+		if need := cellWidth(c); need > span {
+			widths[c.col+c.colSpan-1] += need - span
+		}
+		span = c.rowSpan - 1
+		for i := c.row; i < c.row+c.rowSpan; i++ {
+			span += heights[i]
+		}
+		if need := len(c.lines); need > span {
+			heights[c.row+c.rowSpan-1] += need - span
+		}
+	}
+
+	// Border positions of columns and rows
+	xs := make([]int, columns+1)
+	for j, width := range widths {
+		xs[j+1] = xs[j] + width + 3
+	}
+	ys := make([]int, rows+1)
+	for i, height := range heights {
+		ys[i+1] = ys[i] + height + 1
+	}
+
+	// Draw every cell's border and content on a canvas of display
+	// columns. Neighboring cells share borders, and corners take
+	// precedence over edges.
+	canvas := make([][]string, ys[rows]+1)
+	for y := range canvas {
+		canvas[y] = make([]string, xs[columns]+1)
+		for x := range canvas[y] {
+			canvas[y][x] = " "
+		}
+	}
+	edge := func(y, x int, ch string) {
+		if canvas[y][x] != "+" {
+			canvas[y][x] = ch
+		}
+	}
+	for _, c := range cells {
+		x0, x1 := xs[c.col], xs[c.col+c.colSpan]
+		y0, y1 := ys[c.row], ys[c.row+c.rowSpan]
+		for x := x0; x <= x1; x++ {
+			edge(y0, x, "-")
+			edge(y1, x, "-")
+		}
+		for y := y0; y <= y1; y++ {
+			edge(y, x0, "|")
+			edge(y, x1, "|")
+		}
+		canvas[y0][x0], canvas[y0][x1], canvas[y1][x0], canvas[y1][x1] = "+", "+", "+", "+"
+		for i, line := range c.lines {
+			row, x := canvas[y0+1+i], x0+2
+			for _, r := range line {
+				switch w := runeWidth(r); {
+				case w == 0:
+					// Combining marks join the column before them
+					row[x-1] += string(r)
+				case w == 2:
+					// The second column of a wide character is empty
+					row[x], row[x+1] = string(r), ""
+					x += 2
+				case w == 1:
+					row[x] = string(r)
+					x++
+				}
+			}
+		}
+	}
+	// Mark every column on the outer borders, where older parsers read
+	// the column layout from
+	for _, x := range xs {
+		canvas[0][x], canvas[ys[rows]][x] = "+", "+"
+	}
+	if rows > 1 {
+		for x, ch := range canvas[ys[1]] {
+			if ch == "-" {
+				canvas[ys[1]][x] = "="
+			}
+		}
+	}
+
+	var b strings.Builder
+	for _, line := range canvas {
+		b.WriteString(strings.Join(line, "") + "\n")
+	}
+	return b.String()
+}
+
+// displayWidth returns the number of terminal columns text takes up, as
+// docutils and Pandoc count them: East Asian wide characters take two
+// columns and combining marks none.
+func displayWidth(text string) int {
+	n := 0
+	for _, r := range text {
+		n += runeWidth(r)
+	}
+	return n
+}
+
+// runeWidth returns the number of columns a character takes up.
+func runeWidth(r rune) int {
+	if unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf) {
+		return 0
+	}
+	switch width.LookupRune(r).Kind() {
+	case width.EastAsianWide, width.EastAsianFullwidth:
+		return 2
+	}
+	return 1
+}
@@ -0,0 +1,433 @@
+package document
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"time"
+)
+
+// HTMLRenderer renders the document tree as semantic HTML.
+type HTMLRenderer struct {
+	// Fragment renders only the body content, without the surrounding
+	// page and its <meta> tags.
+	Fragment bool
+	// Suggestions marks suggested insertions and deletions with <ins>
+	// and <del>.
+	Suggestions bool
+}
+
+// Render implements Renderer.
+func (r HTMLRenderer) Render(doc *Document) (string, error) {
+	var b strings.Builder
+
+	if !r.Fragment {
+		b.WriteString("<!DOCTYPE html>\n<html>\n<head>\n")
+		b.WriteString(`<meta charset="utf-8">` + "\n")
+		b.WriteString("<title>" + html.EscapeString(doc.Metadata.Title) + "</title>\n")
+		for _, meta := range metaTags(doc.Metadata) {
+			fmt.Fprintf(&b, "<meta name=\"%s\" content=\"%s\">\n", meta[0], html.EscapeString(meta[1]))
+		}
+		b.WriteString("</head>\n<body>\n")
+	}
+
+	for _, section := range doc.Headers {
+		r.writeSection(&b, "header", section)
+	}
+	r.writeBlocks(&b, doc.Blocks)
+	for _, section := range doc.Footers {
+		r.writeSection(&b, "footer", section)
+	}
+	r.writeFootnotes(&b, doc.Footnotes)
+	r.writeComments(&b, doc.Comments)
+
+	if !r.Fragment {
+		b.WriteString("</body>\n</html>\n")
+	}
+
+	return b.String(), nil
+}
+
+// metaTags returns the name and content of a <meta> tag for each metadata
+// field that is set, named like the frontmatter fields.
+func metaTags(meta Metadata) [][2]string {
+	var tags [][2]string
+	add := func(name, content string) {
+		if content != "" {
+			tags = append(tags, [2]string{name, content})
+		}
+	}
+	date := func(t time.Time) string {
+		if t.IsZero() {
+			return ""
+		}
+		return t.Format(time.RFC3339)
+	}
+
+	add("author", meta.Author)
+	add("created", date(meta.CreatedDate))
+	add("modified", date(meta.ModifiedDate))
+	add("tab", meta.Tab)
+	add("mentions", mentionList(meta.Mentions))
+	add("header", meta.Header)
+	add("header_first_page", meta.HeaderFirstPage)
+	add("header_even_page", meta.HeaderEvenPage)
+	add("footer", meta.Footer)
+	add("footer_first_page", meta.FooterFirstPage)
+	add("footer_even_page", meta.FooterEvenPage)
+	return tags
+}
+
+// writeSection writes a page header or footer as a <header> or <footer>
+// element classed by its variant, e.g. header-first-page.
+func (r HTMLRenderer) writeSection(b *strings.Builder, tag string, section Section) {
+	fmt.Fprintf(b, "<%s class=\"%s\">\n", tag, strings.ReplaceAll(section.Label, " ", "-"))
+	r.writeBlocks(b, section.Blocks)
+	fmt.Fprintf(b, "</%s>\n", tag)
+}
+
+// writeBlocks writes blocks, one or more lines each.
+func (r HTMLRenderer) writeBlocks(b *strings.Builder, blocks []Block) {
+	for _, block := range blocks {
+		r.writeBlock(b, block)
+	}
+}
+
+// writeBlock writes a single block. Empty paragraphs are left out, since
+// HTML spaces paragraphs itself.
+func (r HTMLRenderer) writeBlock(b *strings.Builder, block Block) {
+	switch {
+	case block.Paragraph != nil:
+		if text := r.inlines(block.Paragraph.Inlines); text != "" {
+			b.WriteString("<p>" + text + "</p>\n")
+		}
+	case block.Heading != nil:
+		r.writeHeading(b, block.Heading)
+	case block.List != nil:
+		r.writeList(b, block.List)
+	case block.Table != nil:
+		r.writeTable(b, block.Table)
+	case block.CodeBlock != nil:
+		class := ""
+		if block.CodeBlock.Language != "" {
+			class = ` class="language-` + html.EscapeString(block.CodeBlock.Language) + `"`
+		}
+		b.WriteString("<pre><code" + class + ">" + html.EscapeString(block.CodeBlock.Code) + "</code></pre>\n")
+	case block.Blockquote != nil:
+		b.WriteString("<blockquote>\n")
+		r.writeBlocks(b, block.Blockquote.Blocks)
+		b.WriteString("</blockquote>\n")
+	case block.Break != nil:
+		if block.Break.Kind == PageBreak {
+			b.WriteString(`<div style="page-break-after: always"></div>` + "\n")
+		} else {
+			b.WriteString("<hr>\n")
+		}
+	case block.TableOfContents != nil:
+		r.writeTableOfContents(b, block.TableOfContents)
+	}
+}
+
+// writeHeading writes a heading with its anchor as id.
+func (r HTMLRenderer) writeHeading(b *strings.Builder, heading *Heading) {
+	text := r.inlines(heading.Inlines)
+	if text == "" {
+		return
+	}
+	if heading.Number != "" {
+		text = html.EscapeString(heading.Number) + " " + text
+	}
+	id := ""
+	if heading.Anchor != "" {
+		id = ` id="` + html.EscapeString(heading.Anchor) + `"`
+	}
+	fmt.Fprintf(b, "<h%d%s>%s</h%d>\n", heading.Level, id, text, heading.Level)
+}
+
+// writeList writes a list as <ul> or <ol>. An item's first paragraph is
+// written inline in the <li>.
+func (r HTMLRenderer) writeList(b *strings.Builder, list *List) {
+	tag := "ul"
+	if list.Ordered {
+		tag = "ol"
+	}
+	b.WriteString("<" + tag)
+	if list.Ordered && len(list.Items) > 0 && list.Items[0].Number > 1 {
+		fmt.Fprintf(b, " start=\"%d\"", list.Items[0].Number)
+	}
+	b.WriteString(">\n")
+
+	for _, item := range list.Items {
+		b.WriteString("<li>")
+		if item.Checked != nil {
+			if *item.Checked {
+				b.WriteString(`<input type="checkbox" disabled checked> `)
+			} else {
+				b.WriteString(`<input type="checkbox" disabled> `)
+			}
+		}
+		r.writeFlow(b, item.Blocks)
+		b.WriteString("</li>\n")
+	}
+
+	b.WriteString("</" + tag + ">\n")
+}
+
+// writeFlow writes the content of a list item or table cell, putting a
+// leading paragraph inline and any further blocks on their own lines.
+func (r HTMLRenderer) writeFlow(b *strings.Builder, blocks []Block) {
+	if len(blocks) > 0 && blocks[0].Paragraph != nil {
+		b.WriteString(r.inlines(blocks[0].Paragraph.Inlines))
+		blocks = blocks[1:]
+	}
+	if len(blocks) > 0 {
+		b.WriteString("\n")
+		r.writeBlocks(b, blocks)
+	}
+}
+
+// writeTable writes a table with its first row as the header and merged
+// cells as row and column spans.
+func (r HTMLRenderer) writeTable(b *strings.Builder, table *Table) {
+	b.WriteString("<table>\n")
+	for i, row := range table.Rows {
+		tag := "td"
+		switch i {
+		case 0:
+			tag = "th"
+			b.WriteString("<thead>\n")
+		case 1:
+			b.WriteString("<tbody>\n")
+		}
+
+		b.WriteString("<tr>\n")
+		for _, cell := range row.Cells {
+			b.WriteString("<" + tag)
+			if cell.RowSpan > 1 {
+				fmt.Fprintf(b, " rowspan=\"%d\"", cell.RowSpan)
+			}
+			if cell.ColSpan > 1 {
+				fmt.Fprintf(b, " colspan=\"%d\"", cell.ColSpan)
+			}
+			b.WriteString(">")
+			r.writeFlow(b, cell.Blocks)
+			b.WriteString("</" + tag + ">\n")
+		}
+		b.WriteString("</tr>\n")
+
+		if i == 0 {
+			b.WriteString("</thead>\n")
+		}
+	}
+	if len(table.Rows) > 1 {
+		b.WriteString("</tbody>\n")
+	}
+	b.WriteString("</table>\n")
+}
+
+// writeTableOfContents writes a table of contents as nested lists of
+// links, indented relative to the shallowest heading.
+func (r HTMLRenderer) writeTableOfContents(b *strings.Builder, toc *TableOfContents) {
+	minLevel := 0
+	for _, entry := range toc.Entries {
+		if minLevel == 0 || entry.Level < minLevel {
+			minLevel = entry.Level
+		}
+	}
+
+	b.WriteString(`<nav class="toc">` + "\n")
+	depth := 0
+	for _, entry := range toc.Entries {
+		level := entry.Level - minLevel + 1
+		if depth >= level {
+			b.WriteString("</li>\n")
+			for ; depth > level; depth-- {
+				b.WriteString("</ul>\n</li>\n")
+			}
+		}
+		for depth < level {
+			if depth > 0 {
+				b.WriteString("\n")
+			}
+			b.WriteString("<ul>\n")
+			depth++
+			if depth < level {
+				// Skipped levels get an item that only holds the list
+				b.WriteString("<li>")
+			}
+		}
+		fmt.Fprintf(b, "<li><a href=\"#%s\">%s</a>", html.EscapeString(entry.Anchor), html.EscapeString(entry.Text))
+	}
+	for ; depth > 0; depth-- {
+		b.WriteString("</li>\n</ul>\n")
+	}
+	b.WriteString("</nav>\n")
+}
+
+// writeFootnotes writes footnotes as an ordered list linked to their
+// references.
+func (r HTMLRenderer) writeFootnotes(b *strings.Builder, footnotes []Footnote) {
+	if len(footnotes) == 0 {
+		return
+	}
+	b.WriteString("<section class=\"footnotes\">\n<ol>\n")
+	for i, footnote := range footnotes {
+		fmt.Fprintf(b, "<li id=\"fn-%d\"", footnote.Number)
+		if footnote.Number != i+1 {
+			fmt.Fprintf(b, " value=\"%d\"", footnote.Number)
+		}
+		b.WriteString(">")
+		r.writeFlow(b, footnote.Blocks)
+		fmt.Fprintf(b, " <a href=\"#fnref-%d\">↩</a></li>\n", footnote.Number)
+	}
+	b.WriteString("</ol>\n</section>\n")
+}
+
+// writeComments writes comments with their quoted text and replies.
+func (r HTMLRenderer) writeComments(b *strings.Builder, comments []Comment) {
+	if len(comments) == 0 {
+		return
+	}
+	b.WriteString("<section class=\"comments\">\n<h2>Comments</h2>\n")
+	for _, c := range comments {
+		b.WriteString("<article>\n")
+		if c.QuotedText != "" {
+			b.WriteString("<blockquote>" + html.EscapeString(c.QuotedText) + "</blockquote>\n")
+		}
+		b.WriteString("<p>" + commentByline(c.Author, c.CreatedTime))
+		if c.Resolved {
+			b.WriteString(" ✓ resolved")
+		}
+		b.WriteString(": " + html.EscapeString(c.Content) + "</p>\n")
+		if len(c.Replies) > 0 {
+			b.WriteString("<ul>\n")
+			for _, reply := range c.Replies {
+				b.WriteString("<li>" + commentByline(reply.Author, reply.CreatedTime) + ": " + html.EscapeString(reply.Content) + "</li>\n")
+			}
+			b.WriteString("</ul>\n")
+		}
+		b.WriteString("</article>\n")
+	}
+	b.WriteString("</section>\n")
+}
+
+// commentByline returns the author of a comment or reply in bold, with
+// the date it was written.
+func commentByline(author, created string) string {
+	if author == "" {
+		author = "Unknown"
+	}
+	byline := "<strong>" + html.EscapeString(author) + "</strong>"
+	if t, err := time.Parse(time.RFC3339, created); err == nil {
+		byline += ` <time datetime="` + created + `">` + t.Format("2006-01-02") + "</time>"
+	}
+	return byline
+}
+
+// inlines renders inlines as HTML.
+func (r HTMLRenderer) inlines(inlines []Inline) string {
+	var b strings.Builder
+	for _, inline := range MergeText(inlines) {
+		switch {
+		case inline.Text != nil:
+			b.WriteString(r.text(inline.Text))
+		case inline.LineBreak:
+			b.WriteString("<br>\n")
+		case inline.Image != nil:
+			fmt.Fprintf(&b, "<img src=\"%s\" alt=\"%s\">", html.EscapeString(inline.Image.Src), html.EscapeString(inline.Image.Alt))
+		case inline.FootnoteRef != nil:
+			n := inline.FootnoteRef.Number
+			fmt.Fprintf(&b, "<sup id=\"fnref-%d\"><a href=\"#fn-%d\">%d</a></sup>", n, n, n)
+		case inline.Mention != nil:
+			b.WriteString(mentionHTML(inline.Mention))
+		case inline.Date != nil:
+			value := html.EscapeString(inline.Date.Value)
+			b.WriteString(`<time datetime="` + value + `">` + value + "</time>")
+		case inline.Math != nil:
+			b.WriteString(mathHTML(inline.Math))
+		}
+	}
+	return b.String()
+}
+
+// text renders a run of text with its style, innermost first: code,
+// baseline offset, underline, colors, emphasis, then the link.
+func (r HTMLRenderer) text(t *Text) string {
+	s := t.TextStyle
+	text := html.EscapeString(t.Value)
+
+	if s.Code {
+		text = "<code>" + text + "</code>"
+	}
+	switch s.BaselineOffset {
+	case Superscript:
+		text = "<sup>" + text + "</sup>"
+	case Subscript:
+		text = "<sub>" + text + "</sub>"
+	}
+	if s.Underline {
+		text = "<u>" + text + "</u>"
+	}
+
+	var css []string
+	if s.Color != "" {
+		css = append(css, "color: "+s.Color)
+	}
+	if s.Background != "" && s.Background != HighlightColor {
+		css = append(css, "background-color: "+s.Background)
+	}
+	if len(css) > 0 {
+		text = `<span style="` + strings.Join(css, "; ") + `">` + text + "</span>"
+	}
+	if s.Background == HighlightColor {
+		text = "<mark>" + text + "</mark>"
+	}
+
+	if s.Italic {
+		text = "<em>" + text + "</em>"
+	}
+	if s.Bold {
+		text = "<strong>" + text + "</strong>"
+	}
+	if s.Strikethrough {
+		text = "<s>" + text + "</s>"
+	}
+	if s.Link != "" {
+		text = `<a href="` + html.EscapeString(s.Link) + `">` + text + "</a>"
+	}
+
+	if r.Suggestions {
+		if len(s.SuggestedInsertionIDs) > 0 {
+			text = "<ins>" + text + "</ins>"
+		}
+		if len(s.SuggestedDeletionIDs) > 0 {
+			text = "<del>" + text + "</del>"
+		}
+	}
+
+	return text
+}
+
+// mentionHTML renders a mention as the person's name, linked to their
+// email address.
+func mentionHTML(m *Mention) string {
+	name := m.Name
+	if name == "" {
+		name = m.Email
+	}
+	if m.Email == "" {
+		return html.EscapeString(name)
+	}
+	return `<a href="mailto:` + html.EscapeString(m.Email) + `">` + html.EscapeString(name) + "</a>"
+}
+
+// mathHTML renders an equation as TeX in MathJax and KaTeX delimiters.
+func mathHTML(m *Math) string {
+	if m.TeX == "" {
+		return "<em>[equation]</em>"
+	}
+	if m.Display {
+		return `<span class="math display">\[` + html.EscapeString(m.TeX) + `\]</span>`
+	}
+	return `<span class="math inline">\(` + html.EscapeString(m.TeX) + `\)</span>`
+}
@@ -0,0 +1,152 @@
+package document
+
+import (
+	"testing"
+	"time"
+)
+
+func text(value string, style TextStyle) Inline {
+	return Inline{Text: &Text{Value: value, TextStyle: style}}
+}
+
+func paragraph(inlines ...Inline) Block {
+	return Block{Paragraph: &Paragraph{Inlines: inlines}}
+}
+
+func TestHTMLRenderer(t *testing.T) {
+	checked := true
+	tests := []struct {
+		name   string
+		blocks []Block
+		want   string
+	}{
+		{
+			name: "heading with anchor and number",
+			blocks: []Block{
+				{Heading: &Heading{Level: 2, Style: "HEADING_2", Number: "1.2", Anchor: "setup", Inlines: []Inline{text("Setup", TextStyle{})}}},
+			},
+			want: "<h2 id=\"setup\">1.2 Setup</h2>\n",
+		},
+		{
+			name: "styled text merged across runs",
+			blocks: []Block{paragraph(
+				text("See ", TextStyle{}),
+				text("the ", TextStyle{Bold: true, Link: "https://example.com"}),
+				text("docs", TextStyle{Bold: true, Link: "https://example.com"}),
+				text(" & ", TextStyle{}),
+				text("x", TextStyle{Code: true, BaselineOffset: Superscript}),
+				Inline{LineBreak: true},
+				text("marked", TextStyle{Background: HighlightColor}),
+			)},
+			want: "<p>See <a href=\"https://example.com\"><strong>the docs</strong></a> &amp; <sup><code>x</code></sup><br>\n<mark>marked</mark></p>\n",
+		},
+		{
+			name: "nested lists",
+			blocks: []Block{{List: &List{Ordered: true, Items: []ListItem{
+				{Number: 3, Blocks: []Block{
+					paragraph(text("Install", TextStyle{})),
+					{List: &List{Items: []ListItem{
+						{Checked: &checked, Blocks: []Block{paragraph(text("Linux", TextStyle{}))}},
+					}}},
+				}},
+				{Number: 4, Blocks: []Block{paragraph(text("Run", TextStyle{}))}},
+			}}}},
+			want: "<ol start=\"3\">\n" +
+				"<li>Install\n<ul>\n<li><input type=\"checkbox\" disabled checked> Linux</li>\n</ul>\n</li>\n" +
+				"<li>Run</li>\n" +
+				"</ol>\n",
+		},
+		{
+			name: "table with spans",
+			blocks: []Block{{Table: &Table{Rows: []TableRow{
+				{Cells: []TableCell{
+					{ColSpan: 2, Blocks: []Block{paragraph(text("Name", TextStyle{}))}},
+				}},
+				{Cells: []TableCell{
+					{Blocks: []Block{paragraph(text("a", TextStyle{}))}},
+					{Blocks: []Block{paragraph(text("b", TextStyle{})), paragraph(text("c", TextStyle{}))}},
+				}},
+			}}}},
+			want: "<table>\n<thead>\n<tr>\n<th colspan=\"2\">Name</th>\n</tr>\n</thead>\n" +
+				"<tbody>\n<tr>\n<td>a</td>\n<td>b\n<p>c</p>\n</td>\n</tr>\n</tbody>\n</table>\n",
+		},
+		{
+			name: "code, quote and breaks",
+			blocks: []Block{
+				{CodeBlock: &CodeBlock{Language: "go", Code: "if a < b {}"}},
+				{Blockquote: &Blockquote{Blocks: []Block{paragraph(text("Quoted", TextStyle{}))}}},
+				{Break: &Break{Kind: HorizontalRule}},
+				paragraph(),
+			},
+			want: "<pre><code class=\"language-go\">if a &lt; b {}</code></pre>\n" +
+				"<blockquote>\n<p>Quoted</p>\n</blockquote>\n" +
+				"<hr>\n",
+		},
+		{
+			name: "table of contents",
+			blocks: []Block{{TableOfContents: &TableOfContents{Entries: []TOCEntry{
+				{Level: 1, Text: "Intro", Anchor: "intro"},
+				{Level: 3, Text: "Details", Anchor: "details"},
+				{Level: 1, Text: "End", Anchor: "end"},
+			}}}},
+			want: "<nav class=\"toc\">\n<ul>\n" +
+				"<li><a href=\"#intro\">Intro</a>\n<ul>\n<li>\n<ul>\n<li><a href=\"#details\">Details</a></li>\n</ul>\n</li>\n</ul>\n</li>\n" +
+				"<li><a href=\"#end\">End</a></li>\n" +
+				"</ul>\n</nav>\n",
+		},
+		{
+			name: "footnote reference and chips",
+			blocks: []Block{paragraph(
+				Inline{Mention: &Mention{Name: "Ada", Email: "ada@example.com"}},
+				Inline{FootnoteRef: &FootnoteRef{Number: 1}},
+				Inline{Math: &Math{TeX: `x^{2}`}},
+			)},
+			want: "<p><a href=\"mailto:ada@example.com\">Ada</a><sup id=\"fnref-1\"><a href=\"#fn-1\">1</a></sup><span class=\"math inline\">\\(x^{2}\\)</span></p>\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := HTMLRenderer{Fragment: true}.Render(&Document{Blocks: tt.blocks})
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Render() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHTMLRendererPage(t *testing.T) {
+	doc := &Document{
+		Metadata: Metadata{
+			Title:        "Q3 <Plan>",
+			ModifiedDate: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+			Mentions:     []Mention{{Name: "Ada", Email: "ada@example.com"}},
+		},
+		Blocks:    []Block{paragraph(text("Body", TextStyle{}))},
+		Footnotes: []Footnote{{Number: 1, Blocks: []Block{paragraph(text("Note", TextStyle{}))}}},
+		Comments:  []Comment{{Author: "Bob", Content: "Looks good", CreatedTime: "2024-05-02T08:00:00Z", Resolved: true}},
+	}
+
+	want := "<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n" +
+		"<title>Q3 &lt;Plan&gt;</title>\n" +
+		"<meta name=\"modified\" content=\"2024-05-01T12:00:00Z\">\n" +
+		"<meta name=\"mentions\" content=\"Ada &lt;ada@example.com&gt;\">\n" +
+		"</head>\n<body>\n" +
+		"<p>Body</p>\n" +
+		"<section class=\"footnotes\">\n<ol>\n<li id=\"fn-1\">Note <a href=\"#fnref-1\">↩</a></li>\n</ol>\n</section>\n" +
+		"<section class=\"comments\">\n<h2>Comments</h2>\n<article>\n" +
+		"<p><strong>Bob</strong> <time datetime=\"2024-05-02T08:00:00Z\">2024-05-02</time> ✓ resolved: Looks good</p>\n" +
+		"</article>\n</section>\n" +
+		"</body>\n</html>\n"
+
+	got, err := HTMLRenderer{}.Render(doc)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if got != want {
+		t.Errorf("Render() =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,127 @@
+package document
+
+import (
+	"slices"
+	"strings"
+)
+
+// Inline is an element of running text. Exactly one field is set.
+type Inline struct {
+	Text        *Text        `json:"text,omitempty"`
+	LineBreak   bool         `json:"line_break,omitempty"`
+	Image       *Image       `json:"image,omitempty"`
+	FootnoteRef *FootnoteRef `json:"footnote_ref,omitempty"`
+	Mention     *Mention     `json:"mention,omitempty"`
+	Date        *Date        `json:"date,omitempty"`
+	Math        *Math        `json:"math,omitempty"`
+}
+
+// Text is a run of text with one style.
+type Text struct {
+	Value string `json:"value"`
+	TextStyle
+}
+
+// Baseline offsets.
+const (
+	Superscript = "superscript"
+	Subscript   = "subscript"
+)
+
+// TextStyle is the formatting of a text run. Colors that carry no meaning
+// on their own, such as black text or the default link color, are left
+// out.
+type TextStyle struct {
+	Bold           bool   `json:"bold,omitempty"`
+	Italic         bool   `json:"italic,omitempty"`
+	Strikethrough  bool   `json:"strikethrough,omitempty"`
+	Underline      bool   `json:"underline,omitempty"`
+	Code           bool   `json:"code,omitempty"`
+	BaselineOffset string `json:"baseline_offset,omitempty"`
+	Color          string `json:"color,omitempty"`      // #rrggbb
+	Background     string `json:"background,omitempty"` // #rrggbb
+	Link           string `json:"link,omitempty"`
+
+	// IDs of the suggestions that insert or delete the text
+	SuggestedInsertionIDs []string `json:"suggested_insertion_ids,omitempty"`
+	SuggestedDeletionIDs  []string `json:"suggested_deletion_ids,omitempty"`
+}
+
+// HighlightColor is the background of text marked with the default
+// highlighter.
+const HighlightColor = "#ffff00"
+
+// Equal reports whether two styles are the same.
+func (s TextStyle) Equal(other TextStyle) bool {
+	return s.Bold == other.Bold && s.Italic == other.Italic &&
+		s.Strikethrough == other.Strikethrough && s.Underline == other.Underline &&
+		s.Code == other.Code && s.BaselineOffset == other.BaselineOffset &&
+		s.Color == other.Color && s.Background == other.Background && s.Link == other.Link &&
+		slices.Equal(s.SuggestedInsertionIDs, other.SuggestedInsertionIDs) &&
+		slices.Equal(s.SuggestedDeletionIDs, other.SuggestedDeletionIDs)
+}
+
+// Image is an image, either inline or positioned next to a paragraph.
+type Image struct {
+	Src string `json:"src"`
+	Alt string `json:"alt,omitempty"`
+}
+
+// FootnoteRef is a reference to a footnote.
+type FootnoteRef struct {
+	Number int `json:"number"`
+}
+
+// Date is a date chip.
+type Date struct {
+	// Value is an ISO 8601 date, or date and time if the chip shows one.
+	Value string `json:"value"`
+}
+
+// Math is an equation. TeX is empty if the equation's content could not
+// be recovered.
+type Math struct {
+	TeX     string `json:"tex"`
+	Display bool   `json:"display,omitempty"`
+}
+
+// PlainText returns the text of inlines without formatting. Line breaks
+// become spaces, and mentions, dates and equations are written as their
+// text.
+func PlainText(inlines []Inline) string {
+	var b strings.Builder
+	for _, inline := range inlines {
+		switch {
+		case inline.Text != nil:
+			b.WriteString(inline.Text.Value)
+		case inline.LineBreak:
+			b.WriteString(" ")
+		case inline.Mention != nil && inline.Mention.Name != "":
+			b.WriteString(inline.Mention.Name)
+		case inline.Mention != nil:
+			b.WriteString(inline.Mention.Email)
+		case inline.Date != nil:
+			b.WriteString(inline.Date.Value)
+		case inline.Math != nil:
+			b.WriteString(inline.Math.TeX)
+		}
+	}
+	return b.String()
+}
+
+// MergeText returns the inlines with adjacent text of the same style
+// joined, since Docs often splits text into many runs.
+func MergeText(inlines []Inline) []Inline {
+	var merged []Inline
+	for _, inline := range inlines {
+		if n := len(merged); n > 0 && inline.Text != nil && merged[n-1].Text != nil &&
+			merged[n-1].Text.TextStyle.Equal(inline.Text.TextStyle) {
+			text := *merged[n-1].Text
+			text.Value += inline.Text.Value
+			merged[n-1].Text = &text
+			continue
+		}
+		merged = append(merged, inline)
+	}
+	return merged
+}
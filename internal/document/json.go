@@ -0,0 +1,18 @@
+package document
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// JSONRenderer renders the document tree as indented JSON.
+type JSONRenderer struct{}
+
+// Render implements Renderer.
+func (JSONRenderer) Render(doc *Document) (string, error) {
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal document: %w", err)
+	}
+	return string(data) + "\n", nil
+}
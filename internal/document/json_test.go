@@ -0,0 +1,77 @@
+package document
+
+import (
+	"testing"
+)
+
+func TestJSONRenderer(t *testing.T) {
+	checked := true
+	doc := &Document{
+		Metadata: Metadata{Title: "Notes"},
+		Blocks: []Block{
+			{Heading: &Heading{Level: 1, Style: "HEADING_1", Anchor: "intro", Inlines: []Inline{
+				{Text: &Text{Value: "Intro"}},
+			}}},
+			{List: &List{Items: []ListItem{{Checked: &checked, Blocks: []Block{
+				{Paragraph: &Paragraph{Inlines: []Inline{
+					{Text: &Text{Value: "Done", TextStyle: TextStyle{Bold: true, Link: "https://example.com"}}},
+				}}},
+			}}}}},
+		},
+	}
+
+	want := `{
+  "metadata": {
+    "title": "Notes"
+  },
+  "blocks": [
+    {
+      "heading": {
+        "level": 1,
+        "style": "HEADING_1",
+        "anchor": "intro",
+        "inlines": [
+          {
+            "text": {
+              "value": "Intro"
+            }
+          }
+        ]
+      }
+    },
+    {
+      "list": {
+        "items": [
+          {
+            "checked": true,
+            "blocks": [
+              {
+                "paragraph": {
+                  "inlines": [
+                    {
+                      "text": {
+                        "value": "Done",
+                        "bold": true,
+                        "link": "https://example.com"
+                      }
+                    }
+                  ]
+                }
+              }
+            ]
+          }
+        ]
+      }
+    }
+  ]
+}
+`
+
+	got, err := JSONRenderer{}.Render(doc)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if got != want {
+		t.Errorf("Render() =\n%s\nwant\n%s", got, want)
+	}
+}
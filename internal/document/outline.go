@@ -0,0 +1,41 @@
+package document
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Outline numbers headings hierarchically, like 1.2.1, by their depth in
+// the outline rather than their level. A heading that skips levels is
+// numbered as a child of the heading above it, so numbers have no zero
+// components. The zero value is ready to use.
+type Outline struct {
+	levels   []int // levels of the open headings, outermost first
+	counters []int // counters per depth, kept past the open headings so siblings continue counting
+}
+
+// Next returns the number of the next heading at level.
+func (o *Outline) Next(level int) string {
+	for len(o.levels) > 0 && o.levels[len(o.levels)-1] > level {
+		o.levels = o.levels[:len(o.levels)-1]
+	}
+
+	depth := len(o.levels)
+	if depth > 0 && o.levels[depth-1] == level {
+		depth--
+	} else {
+		o.levels = append(o.levels, level)
+	}
+	if depth < len(o.counters) {
+		o.counters[depth]++
+		o.counters = o.counters[:depth+1]
+	} else {
+		o.counters = append(o.counters, 1)
+	}
+
+	parts := make([]string, len(o.counters))
+	for i, n := range o.counters {
+		parts[i] = strconv.Itoa(n)
+	}
+	return strings.Join(parts, ".")
+}
@@ -0,0 +1,43 @@
+package document
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestOutline(t *testing.T) {
+	tests := []struct {
+		name   string
+		levels []int
+		want   []string
+	}{
+		{
+			name:   "nested",
+			levels: []int{1, 2, 2, 3, 1, 2},
+			want:   []string{"1", "1.1", "1.2", "1.2.1", "2", "2.1"},
+		},
+		{
+			name:   "skipped levels",
+			levels: []int{1, 3, 2, 4, 1},
+			want:   []string{"1", "1.1", "1.2", "1.2.1", "2"},
+		},
+		{
+			name:   "starts below the top level",
+			levels: []int{2, 3, 1, 2},
+			want:   []string{"1", "1.1", "2", "2.1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var outline Outline
+			var got []string
+			for _, level := range tt.levels {
+				got = append(got, outline.Next(level))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Next() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
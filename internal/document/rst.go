@@ -0,0 +1,483 @@
+package document
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// RSTRenderer renders the document tree as reStructuredText, as read by
+// docutils and Sphinx.
+type RSTRenderer struct{}
+
+// Render implements Renderer.
+func (RSTRenderer) Render(doc *Document) (string, error) {
+	w := &rstWriter{title: doc.Metadata.Title}
+
+	var parts []string
+	if header := rstHeader(doc.Metadata); header != "" {
+		parts = append(parts, header)
+	}
+	for _, section := range append(doc.Headers, doc.Footers...) {
+		if text := w.section(section); text != "" {
+			parts = append(parts, text)
+		}
+	}
+	if body := w.blocks(doc.Blocks); body != "" {
+		parts = append(parts, body)
+	}
+	for _, footnote := range doc.Footnotes {
+		if text := w.blocks(footnote.Blocks); text != "" {
+			parts = append(parts, fmt.Sprintf(".. [%d] %s", footnote.Number, indentRest(text, "   ")))
+		}
+	}
+	if len(doc.Comments) > 0 {
+		parts = append(parts, w.comments(doc.Comments))
+	}
+	parts = append(parts, w.substitutions...)
+
+	return strings.Join(parts, "\n"), nil
+}
+
+// rstHeader returns the document title and the metadata as a
+// bibliographic field list.
+func rstHeader(meta Metadata) string {
+	var b strings.Builder
+	if meta.Title != "" {
+		title := rstEscape(strings.ReplaceAll(meta.Title, "\n", " "))
+		rule := strings.Repeat("=", displayWidth(title))
+		b.WriteString(rule + "\n" + title + "\n" + rule + "\n\n")
+	}
+	var fields strings.Builder
+	field := func(name, value string) {
+		if value != "" {
+			fields.WriteString(":" + name + ": " + rstEscape(strings.ReplaceAll(value, "\n", " ")) + "\n")
+		}
+	}
+	field("Author", meta.Author)
+	if !meta.CreatedDate.IsZero() {
+		field("Created", meta.CreatedDate.Format(time.RFC3339))
+	}
+	if !meta.ModifiedDate.IsZero() {
+		field("Date", meta.ModifiedDate.Format("2006-01-02"))
+	}
+	field("Tab", meta.Tab)
+	field("Mentions", mentionList(meta.Mentions))
+	field("Header", meta.Header)
+	field("Header first page", meta.HeaderFirstPage)
+	field("Header even page", meta.HeaderEvenPage)
+	field("Footer", meta.Footer)
+	field("Footer first page", meta.FooterFirstPage)
+	field("Footer even page", meta.FooterEvenPage)
+	if fields.Len() > 0 {
+		b.WriteString(fields.String() + "\n")
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// rstWriter holds the state of rendering one document.
+type rstWriter struct {
+	// title is the document title, which a TITLE heading is not repeated
+	// after
+	title string
+	// substitutions holds the definitions of inline images, written at
+	// the end of the document
+	substitutions []string
+	// levels holds the levels of the open sections, outermost first
+	levels []int
+}
+
+// section renders the default page header or footer with the header and
+// footer directives. Docutils has no first or even page variants, so
+// those are left out.
+func (w *rstWriter) section(section Section) string {
+	if section.Label != section.Kind {
+		return ""
+	}
+	text := w.blocks(section.Blocks)
+	if text == "" {
+		return ""
+	}
+	return ".. " + section.Kind + "::\n\n" + indentLines(text, "   ")
+}
+
+// blocks renders blocks separated by blank lines.
+func (w *rstWriter) blocks(blocks []Block) string {
+	var parts []string
+	for i, block := range blocks {
+		text := w.block(block)
+		if text == "" {
+			continue
+		}
+		// An empty comment ends the previous block, so an indented quote
+		// or a second list is not read as part of it
+		if i > 0 && (block.Blockquote != nil && blocks[i-1].Paragraph == nil && blocks[i-1].Heading == nil ||
+			block.List != nil && blocks[i-1].List != nil) {
+			text = "..\n\n" + text
+		}
+		parts = append(parts, text)
+	}
+	return strings.Join(parts, "\n")
+}
+
+// block renders a single block, ending with a newline.
+func (w *rstWriter) block(block Block) string {
+	switch {
+	case block.Paragraph != nil:
+		return w.paragraph(block.Paragraph)
+	case block.Heading != nil:
+		return w.heading(block.Heading)
+	case block.List != nil:
+		return w.list(block.List)
+	case block.Table != nil:
+		return w.table(block.Table)
+	case block.CodeBlock != nil:
+		directive := "::"
+		if block.CodeBlock.Language != "" {
+			directive = ".. code-block:: " + block.CodeBlock.Language
+		}
+		return directive + "\n\n" + indentLines(block.CodeBlock.Code+"\n", "   ")
+	case block.Blockquote != nil:
+		return indentLines(w.blocks(block.Blockquote.Blocks), "   ")
+	case block.Break != nil:
+		if block.Break.Kind == PageBreak {
+			return ".. raw:: latex\n\n   \\newpage\n"
+		}
+		return "----------\n"
+	case block.TableOfContents != nil:
+		return ".. contents::\n"
+	}
+	return ""
+}
+
+// paragraph renders a paragraph. Paragraphs with line breaks become line
+// blocks, and a paragraph holding only an image or a display equation
+// becomes the matching directive.
+func (w *rstWriter) paragraph(paragraph *Paragraph) string {
+	if len(paragraph.Inlines) == 1 {
+		switch inline := paragraph.Inlines[0]; {
+		case inline.Image != nil:
+			return rstImage("image", inline.Image)
+		case inline.Math != nil && inline.Math.Display:
+			return ".. math::\n\n   " + inline.Math.TeX + "\n"
+		}
+	}
+
+	text := w.inlines(paragraph.Inlines)
+	if text == "" {
+		return ""
+	}
+	lines := strings.Split(text, "\n")
+	if len(lines) > 1 {
+		for i, line := range lines {
+			lines[i] = "| " + line
+		}
+		return strings.Join(lines, "\n") + "\n"
+	}
+	return rstLineStart(text) + "\n"
+}
+
+// rstAdornments are the underline characters of section depths 1-6.
+var rstAdornments = []string{"=", "-", "~", "^", `"`, "'"}
+
+// heading renders a section title with a target for its anchor.
+// docutils ranks adornments by the order they first appear in, so they
+// follow the depth of the section rather than the heading level, and a
+// heading that skips levels is nested one section deeper.
+func (w *rstWriter) heading(heading *Heading) string {
+	text := strings.ReplaceAll(w.inlines(heading.Inlines), "\n", " ")
+	if text == "" || repeatsTitle(heading, w.title) {
+		return ""
+	}
+	if heading.Number != "" {
+		text = heading.Number + " " + text
+	}
+	var b strings.Builder
+	if heading.Anchor != "" {
+		b.WriteString(".. _" + heading.Anchor + ":\n\n")
+	}
+	for len(w.levels) > 0 && w.levels[len(w.levels)-1] >= heading.Level {
+		w.levels = w.levels[:len(w.levels)-1]
+	}
+	w.levels = append(w.levels, heading.Level)
+	adornment := rstAdornments[min(len(w.levels), len(rstAdornments))-1]
+	b.WriteString(text + "\n" + strings.Repeat(adornment, displayWidth(text)) + "\n")
+	return b.String()
+}
+
+// list renders a list. Blocks after an item's first paragraph, such as
+// nested lists, are indented to the item's text between blank lines.
+func (w *rstWriter) list(list *List) string {
+	var b strings.Builder
+	for i, item := range list.Items {
+		marker := "- "
+		if list.Ordered {
+			marker = fmt.Sprintf("%d. ", item.Number)
+		}
+		indent := strings.Repeat(" ", len(marker))
+
+		blocks := item.Blocks
+		text := ""
+		if len(blocks) > 0 && blocks[0].Paragraph != nil {
+			text = strings.TrimSuffix(w.paragraph(blocks[0].Paragraph), "\n")
+			blocks = blocks[1:]
+		}
+		if item.Checked != nil && *item.Checked {
+			text = "[x] " + text
+		} else if item.Checked != nil {
+			text = "[ ] " + text
+		}
+		b.WriteString(strings.TrimRight(marker+indentRest(text, indent), " ") + "\n")
+
+		if rest := w.blocks(blocks); rest != "" {
+			b.WriteString("\n" + indentLines(rest, indent))
+			if i < len(list.Items)-1 {
+				b.WriteString("\n")
+			}
+		}
+	}
+	return b.String()
+}
+
+// table renders a table as a list table, or as a grid table if it has
+// merged cells. The first row is the header.
+func (w *rstWriter) table(table *Table) string {
+	for _, row := range table.Rows {
+		for _, cell := range row.Cells {
+			if cell.RowSpan > 1 || cell.ColSpan > 1 {
+				return GridTable(table, func(cell TableCell) string {
+					return w.blocks(cell.Blocks)
+				})
+			}
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(".. list-table::\n   :header-rows: 1\n\n")
+	for _, row := range table.Rows {
+		for j, cell := range row.Cells {
+			prefix := "     - "
+			if j == 0 {
+				prefix = "   * - "
+			}
+			content := strings.TrimSuffix(w.blocks(cell.Blocks), "\n")
+			b.WriteString(strings.TrimRight(prefix+indentRest(content, "       "), " ") + "\n")
+		}
+	}
+	return b.String()
+}
+
+// comments renders comments as a section with their quoted text and
+// replies.
+func (w *rstWriter) comments(comments []Comment) string {
+	parts := []string{"Comments\n========\n"}
+	for i, c := range comments {
+		var b strings.Builder
+		if i > 0 && len(comments[i-1].Replies) > 0 {
+			b.WriteString("..\n\n")
+		}
+		if c.QuotedText != "" {
+			b.WriteString(indentLines(rstEscape(c.QuotedText)+"\n", "   ") + "\n")
+		}
+		b.WriteString(rstByline(c.Author, c.CreatedTime))
+		if c.Resolved {
+			b.WriteString(" ✓ resolved")
+		}
+		b.WriteString(": " + rstEscape(c.Content) + "\n")
+		if len(c.Replies) > 0 {
+			b.WriteString("\n")
+			for _, r := range c.Replies {
+				b.WriteString("- " + rstByline(r.Author, r.CreatedTime) + ": " + rstEscape(r.Content) + "\n")
+			}
+		}
+		parts = append(parts, b.String())
+	}
+	return strings.Join(parts, "\n")
+}
+
+// rstByline returns the author of a comment or reply in bold, with the
+// date it was written.
+func rstByline(author, created string) string {
+	if author == "" {
+		author = "Unknown"
+	}
+	byline := "**" + rstEscape(author) + "**"
+	if t, err := time.Parse(time.RFC3339, created); err == nil {
+		byline += " (" + t.Format("2006-01-02") + ")"
+	}
+	return byline
+}
+
+// rstPiece is a part of inline text: either literal text or inline markup.
+type rstPiece struct {
+	text   string
+	markup bool
+}
+
+// inlines renders inlines as reStructuredText. Line breaks are returned
+// as newlines for the caller to render as a line block.
+func (w *rstWriter) inlines(inlines []Inline) string {
+	var pieces []rstPiece
+	plain := func(s string) {
+		if s != "" {
+			pieces = append(pieces, rstPiece{text: s})
+		}
+	}
+	markup := func(s string) {
+		pieces = append(pieces, rstPiece{text: s, markup: true})
+	}
+
+	for _, inline := range MergeText(inlines) {
+		switch {
+		case inline.Text != nil:
+			value := inline.Text.Value
+			core := strings.TrimSpace(value)
+			styled := rstStyle(core, inline.Text.TextStyle)
+			if core == "" || styled == "" {
+				plain(rstEscape(value))
+				continue
+			}
+			start := strings.Index(value, core)
+			plain(rstEscape(value[:start]))
+			markup(styled)
+			plain(rstEscape(value[start+len(core):]))
+		case inline.LineBreak:
+			plain("\n")
+		case inline.Image != nil:
+			name := fmt.Sprintf("image%d", len(w.substitutions)+1)
+			w.substitutions = append(w.substitutions, rstImage("|"+name+"| image", inline.Image))
+			markup("|" + name + "|")
+		case inline.FootnoteRef != nil:
+			markup(fmt.Sprintf("[%d]_", inline.FootnoteRef.Number))
+		case inline.Mention != nil:
+			m := inline.Mention
+			name := m.Name
+			if name == "" {
+				name = m.Email
+			}
+			if m.Email == "" {
+				plain(rstEscape(name))
+			} else {
+				markup(rstLink(name, "mailto:"+m.Email))
+			}
+		case inline.Date != nil:
+			plain(inline.Date.Value)
+		case inline.Math != nil:
+			if inline.Math.TeX == "" {
+				markup("*[equation]*")
+			} else {
+				markup(":math:`" + strings.ReplaceAll(inline.Math.TeX, "`", "\\`") + "`")
+			}
+		}
+	}
+
+	// Inline markup must be separated from surrounding text by
+	// whitespace or punctuation; an escaped space joins it to a word
+	var b strings.Builder
+	for i, p := range pieces {
+		if i > 0 {
+			prev := pieces[i-1]
+			before, _ := utf8.DecodeLastRuneInString(prev.text)
+			after, _ := utf8.DecodeRuneInString(p.text)
+			if p.markup && (prev.markup || !isRSTBoundary(before, `'"([{<-/:`)) ||
+				!p.markup && prev.markup && !isRSTBoundary(after, `'")]}>-/:.,;!?\`) {
+				b.WriteString(`\ `)
+			}
+		}
+		b.WriteString(p.text)
+	}
+	return b.String()
+}
+
+// isRSTBoundary reports whether r may border inline markup.
+func isRSTBoundary(r rune, punctuation string) bool {
+	return unicode.IsSpace(r) || strings.ContainsRune(punctuation, r)
+}
+
+// rstStyle renders styled text as inline markup, or returns an empty
+// string if the style has none. reStructuredText markup cannot nest, so
+// links and code win over emphasis, and bold over italic.
+func rstStyle(text string, s TextStyle) string {
+	switch {
+	case s.Link != "":
+		if anchor, ok := strings.CutPrefix(s.Link, "#"); ok {
+			return rstLink(text, anchor+"_")
+		}
+		return rstLink(text, s.Link)
+	case s.Code:
+		return "``" + text + "``"
+	case s.BaselineOffset == Superscript:
+		return ":sup:`" + strings.ReplaceAll(text, "`", "\\`") + "`"
+	case s.BaselineOffset == Subscript:
+		return ":sub:`" + strings.ReplaceAll(text, "`", "\\`") + "`"
+	case s.Bold:
+		return "**" + rstEscape(text) + "**"
+	case s.Italic:
+		return "*" + rstEscape(text) + "*"
+	}
+	return ""
+}
+
+// rstLink renders an anonymous hyperlink reference.
+func rstLink(text, target string) string {
+	text = strings.NewReplacer("`", "\\`", "<", "\\<").Replace(text)
+	return "`" + text + " <" + target + ">`__"
+}
+
+// rstImage renders an image directive, or a substitution definition if
+// directive names one.
+func rstImage(directive string, image *Image) string {
+	text := ".. " + directive + ":: " + image.Src + "\n"
+	if image.Alt != "" {
+		text += "   :alt: " + image.Alt + "\n"
+	}
+	return text
+}
+
+// rstEscaper escapes characters that start inline markup.
+var rstEscaper = strings.NewReplacer(`\`, `\\`, "*", `\*`, "`", "\\`", "|", `\|`)
+
+// rstReferenceEnd matches underscores that would end a reference name.
+var rstReferenceEnd = regexp.MustCompile(`_(\W|$)`)
+
+// rstEscape escapes literal text.
+func rstEscape(s string) string {
+	s = rstEscaper.Replace(s)
+	return rstReferenceEnd.ReplaceAllString(s, `\_$1`)
+}
+
+// rstBlockStart matches line starts that reStructuredText reads as block
+// syntax: list markers, enumerators, directives, fields and quotes.
+var rstBlockStart = regexp.MustCompile(`^([-+:>]|\.\.|(\d+|#|[a-zA-Z])[.)] )`)
+
+// rstLineStart keeps literal text at the start of a line from being read
+// as block syntax.
+func rstLineStart(line string) string {
+	if rstBlockStart.MatchString(line) {
+		return `\` + line
+	}
+	return line
+}
+
+// indentLines indents every non-empty line of text.
+func indentLines(text, indent string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = indent + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// indentRest indents every non-empty line of text but the first.
+func indentRest(text, indent string) string {
+	first, rest, ok := strings.Cut(text, "\n")
+	if !ok {
+		return text
+	}
+	return first + "\n" + indentLines(rest, indent)
+}
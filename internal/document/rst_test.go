@@ -0,0 +1,52 @@
+package document
+
+import "testing"
+
+func TestRSTHeaderTitle(t *testing.T) {
+	tests := []struct {
+		title string
+		want  string
+	}{
+		{title: "Plan", want: "====\nPlan\n====\n"},
+		{title: "*Draft* plan_v2", want: "=================\n\\*Draft\\* plan_v2\n=================\n"},
+		{title: "A | B", want: "======\nA \\| B\n======\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			if got := rstHeader(Metadata{Title: tt.title}); got != tt.want {
+				t.Errorf("rstHeader() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRSTDisplayWidth(t *testing.T) {
+	cell := func(value string, colSpan int) TableCell {
+		return TableCell{ColSpan: colSpan, Blocks: []Block{paragraph(text(value, TextStyle{}))}}
+	}
+	doc := &Document{Blocks: []Block{
+		{Heading: &Heading{Level: 1, Style: "HEADING_1", Inlines: []Inline{text("リリース計画", TextStyle{})}}},
+		{Table: &Table{Rows: []TableRow{
+			{Cells: []TableCell{cell("チーム", 2)}},
+			{Cells: []TableCell{cell("名前", 0), cell("Role", 0)}},
+			{Cells: []TableCell{cell("Zoe\u0308", 0), cell("開発者", 0)}},
+		}}},
+	}}
+
+	got, err := RSTRenderer{}.Render(doc)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	want := "リリース計画\n============\n\n" +
+		"+------+--------+\n" +
+		"| チーム        |\n" +
+		"+======+========+\n" +
+		"| 名前 | Role   |\n" +
+		"+------+--------+\n" +
+		"| Zoe\u0308  | 開発者 |\n" +
+		"+------+--------+\n"
+	if got != want {
+		t.Errorf("Render() =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,316 @@
+package document
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// TextLinks selects how link URLs are written in plain text.
+type TextLinks string
+
+const (
+	// TextLinksNone writes only the link text.
+	TextLinksNone TextLinks = "none"
+	// TextLinksInline writes the URL in parentheses after the link text.
+	TextLinksInline TextLinks = "inline"
+	// TextLinksEnd numbers links like [1] and lists their URLs at the end.
+	TextLinksEnd TextLinks = "end"
+)
+
+// TextRenderer renders the document tree as compact plain text for
+// language model prompts: no markup, headings as numbered outline lines
+// and tables as key: value rows. Page headers and footers, breaks and
+// tables of contents are left out.
+type TextRenderer struct {
+	// Links selects how link URLs are written. The zero value omits them.
+	Links TextLinks
+}
+
+// Render implements Renderer.
+func (r TextRenderer) Render(doc *Document) (string, error) {
+	w := &textWriter{links: r.Links, title: doc.Metadata.Title}
+
+	var b strings.Builder
+	if doc.Metadata.Title != "" {
+		b.WriteString(oneLine(doc.Metadata.Title) + "\n\n")
+	}
+	w.outline = outlineNumbers(doc.Blocks)
+	b.WriteString(w.blocks(doc.Blocks, ""))
+
+	if len(doc.Footnotes) > 0 {
+		b.WriteString("\nFootnotes:\n")
+		for _, footnote := range doc.Footnotes {
+			text := strings.TrimSpace(w.blocks(footnote.Blocks, ""))
+			fmt.Fprintf(&b, "[^%d] %s\n", footnote.Number, strings.ReplaceAll(text, "\n", " "))
+		}
+	}
+	if len(doc.Comments) > 0 {
+		b.WriteString("\nComments:\n")
+		for _, c := range doc.Comments {
+			b.WriteString(textComment(c.Author, c.Content, c.QuotedText))
+			for _, reply := range c.Replies {
+				b.WriteString("  " + textComment(reply.Author, reply.Content, ""))
+			}
+		}
+	}
+	if len(w.urls) > 0 {
+		b.WriteString("\nLinks:\n")
+		for i, url := range w.urls {
+			fmt.Fprintf(&b, "[%d] %s\n", i+1, url)
+		}
+	}
+
+	// Blocks add their own blank lines, so collapse runs of them
+	text := strings.TrimLeft(b.String(), "\n")
+	for strings.Contains(text, "\n\n\n") {
+		text = strings.ReplaceAll(text, "\n\n\n", "\n\n")
+	}
+	return text, nil
+}
+
+// EstimateTokens estimates the number of language model tokens in text,
+// at about four characters per token.
+func EstimateTokens(text string) int {
+	return (utf8.RuneCountInString(text) + 3) / 4
+}
+
+// textWriter holds the state of rendering one document as plain text.
+type textWriter struct {
+	links TextLinks
+	title string
+	// outline holds the outline numbers of headings
+	outline map[*Heading]string
+	// urls holds the URLs of numbered links, in order of first use
+	urls []string
+}
+
+// outlineNumbers numbers a document's headings hierarchically. Headings
+// that are already numbered keep their number, and the title and subtitle
+// are not numbered.
+func outlineNumbers(blocks []Block) map[*Heading]string {
+	numbers := make(map[*Heading]string)
+	var outline Outline
+	for _, block := range blocks {
+		h := block.Heading
+		if h == nil || isTitleStyle(h.Style) {
+			continue
+		}
+		numbers[h] = outline.Next(h.Level)
+		if h.Number != "" {
+			numbers[h] = h.Number
+		}
+	}
+	return numbers
+}
+
+// isTitleStyle reports whether a heading is the document's title or
+// subtitle.
+func isTitleStyle(style string) bool {
+	return style == "TITLE" || style == "SUBTITLE"
+}
+
+// blocks renders blocks one per line, with lists indented by indent.
+// Headings start after a blank line.
+func (w *textWriter) blocks(blocks []Block, indent string) string {
+	var b strings.Builder
+	for _, block := range blocks {
+		switch {
+		case block.Paragraph != nil:
+			if text := w.inlines(block.Paragraph.Inlines); text != "" {
+				b.WriteString(indent + strings.ReplaceAll(text, "\n", "\n"+indent) + "\n")
+			}
+		case block.Heading != nil:
+			text := oneLine(w.inlines(block.Heading.Inlines))
+			if text == "" || repeatsTitle(block.Heading, w.title) {
+				continue
+			}
+			if number := w.outline[block.Heading]; number != "" {
+				text = number + " " + text
+			}
+			b.WriteString("\n" + text + "\n")
+		case block.List != nil:
+			w.list(&b, block.List, indent)
+		case block.Table != nil:
+			w.table(&b, block.Table, indent)
+		case block.CodeBlock != nil:
+			b.WriteString(indent + strings.ReplaceAll(block.CodeBlock.Code, "\n", "\n"+indent) + "\n")
+		case block.Blockquote != nil:
+			quote := strings.TrimRight(w.blocks(block.Blockquote.Blocks, ""), "\n")
+			b.WriteString(indent + "> " + strings.ReplaceAll(quote, "\n", "\n"+indent+"> ") + "\n")
+		}
+	}
+	return b.String()
+}
+
+// list writes a list with one line per item, nested lists indented by two
+// spaces.
+func (w *textWriter) list(b *strings.Builder, list *List, indent string) {
+	for _, item := range list.Items {
+		marker := "- "
+		if list.Ordered {
+			marker = fmt.Sprintf("%d. ", item.Number)
+		}
+		if item.Checked != nil && *item.Checked {
+			marker += "[x] "
+		} else if item.Checked != nil {
+			marker += "[ ] "
+		}
+
+		blocks := item.Blocks
+		if len(blocks) > 0 && blocks[0].Paragraph != nil {
+			text := oneLine(w.inlines(blocks[0].Paragraph.Inlines))
+			b.WriteString(indent + marker + text + "\n")
+			blocks = blocks[1:]
+		}
+		b.WriteString(w.blocks(blocks, indent+"  "))
+	}
+}
+
+// table writes each row after the header as key: value pairs, keyed by
+// the header cell of each column. Merged cells give their value to every
+// row and column they cover, and columns under a merged header cell are
+// written as one pair.
+func (w *textWriter) table(b *strings.Builder, table *Table, indent string) {
+	cells, columns := placeCells(table)
+	if columns == 0 {
+		return
+	}
+	grid := make([][]string, len(table.Rows))
+	for r := range grid {
+		grid[r] = make([]string, columns)
+	}
+	for _, c := range cells {
+		text := oneLine(strings.ReplaceAll(w.blocks(c.cell.Blocks, ""), "\n", " "))
+		for i := c.row; i < c.row+c.rowSpan; i++ {
+			for j := c.col; j < c.col+c.colSpan; j++ {
+				grid[i][j] = text
+			}
+		}
+	}
+
+	keys := grid[0]
+	if len(grid) == 1 {
+		b.WriteString(indent + strings.Join(keys, "; ") + "\n")
+		return
+	}
+	for _, row := range grid[1:] {
+		var pairs []string
+		for j, value := range row {
+			if value == "" {
+				continue
+			}
+			// Columns under a merged header cell share one key
+			if j > 0 && keys[j] != "" && keys[j] == keys[j-1] && row[j-1] != "" {
+				if value != row[j-1] {
+					pairs[len(pairs)-1] += ", " + value
+				}
+				continue
+			}
+			key := keys[j]
+			if key == "" {
+				key = fmt.Sprintf("Column %d", j+1)
+			}
+			pairs = append(pairs, key+": "+value)
+		}
+		if len(pairs) > 0 {
+			b.WriteString(indent + strings.Join(pairs, "; ") + "\n")
+		}
+	}
+}
+
+// inlines renders inlines as plain text. Line breaks are kept as
+// newlines.
+func (w *textWriter) inlines(inlines []Inline) string {
+	var b strings.Builder
+	inlines = MergeText(inlines)
+	for i := 0; i < len(inlines); i++ {
+		switch inline := inlines[i]; {
+		case inline.Text != nil:
+			// Text styles are dropped, so a link split into differently
+			// styled runs is one link
+			value := inline.Text.Value
+			for i+1 < len(inlines) && inlines[i+1].Text != nil && inlines[i+1].Text.Link == inline.Text.Link {
+				i++
+				value += inlines[i].Text.Value
+			}
+			b.WriteString(w.text(value, inline.Text.Link))
+		case inline.LineBreak:
+			b.WriteString("\n")
+		case inline.Image != nil:
+			if inline.Image.Alt != "" {
+				b.WriteString("[image: " + oneLine(inline.Image.Alt) + "]")
+			} else {
+				b.WriteString("[image]")
+			}
+		case inline.FootnoteRef != nil:
+			fmt.Fprintf(&b, "[^%d]", inline.FootnoteRef.Number)
+		case inline.Mention != nil:
+			if inline.Mention.Name != "" {
+				b.WriteString(inline.Mention.Name)
+			} else {
+				b.WriteString(inline.Mention.Email)
+			}
+		case inline.Date != nil:
+			b.WriteString(inline.Date.Value)
+		case inline.Math != nil:
+			if inline.Math.TeX == "" {
+				b.WriteString("[equation]")
+			} else {
+				b.WriteString(inline.Math.TeX)
+			}
+		}
+	}
+
+	lines := strings.Split(b.String(), "\n")
+	for i, line := range lines {
+		lines[i] = oneLine(line)
+	}
+	return strings.Trim(strings.Join(lines, "\n"), "\n")
+}
+
+// text renders text with the URL it links to, if any. Links to headings
+// in the document are written as their text.
+func (w *textWriter) text(value, url string) string {
+	if url == "" || strings.HasPrefix(url, "#") {
+		return value
+	}
+
+	// Bare URLs already show where they lead
+	core := strings.TrimSpace(value)
+	if core == "" || core == url {
+		return value
+	}
+	trailing := value[strings.LastIndex(value, core)+len(core):]
+	switch w.links {
+	case TextLinksInline:
+		return strings.TrimSuffix(value, trailing) + " (" + url + ")" + trailing
+	case TextLinksEnd:
+		n := slices.Index(w.urls, url) + 1
+		if n == 0 {
+			w.urls = append(w.urls, url)
+			n = len(w.urls)
+		}
+		return strings.TrimSuffix(value, trailing) + fmt.Sprintf(" [%d]", n) + trailing
+	}
+	return value
+}
+
+// textComment writes a comment or reply on one line, with the text it
+// refers to.
+func textComment(author, content, quoted string) string {
+	if author == "" {
+		author = "Unknown"
+	}
+	if quoted != "" {
+		author += ` on "` + oneLine(quoted) + `"`
+	}
+	return author + ": " + oneLine(content) + "\n"
+}
+
+// oneLine collapses runs of whitespace, including newlines, into single
+// spaces.
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
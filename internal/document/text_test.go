@@ -0,0 +1,77 @@
+package document
+
+import "testing"
+
+func TestTextRendererLinks(t *testing.T) {
+	blocks := []Block{
+		paragraph(
+			text("Read ", TextStyle{}),
+			text("the docs", TextStyle{Link: "https://example.com/docs"}),
+			text(", ", TextStyle{}),
+			text("the FAQ ", TextStyle{Bold: true, Link: "https://example.com/faq"}),
+			text("and ", TextStyle{}),
+			text("docs", TextStyle{Italic: true, Link: "https://example.com/docs"}),
+		),
+		paragraph(text("https://example.com/raw", TextStyle{Link: "https://example.com/raw"})),
+		paragraph(
+			text("See ", TextStyle{}),
+			text("the ", TextStyle{Link: "https://example.com/guide"}),
+			text("guide", TextStyle{Bold: true, Link: "https://example.com/guide"}),
+			text(".", TextStyle{}),
+		),
+	}
+
+	tests := []struct {
+		name  string
+		links TextLinks
+		want  string
+	}{
+		{
+			name:  "omitted by default",
+			links: "",
+			want:  "Read the docs, the FAQ and docs\nhttps://example.com/raw\nSee the guide.\n",
+		},
+		{
+			name:  "inline",
+			links: TextLinksInline,
+			want: "Read the docs (https://example.com/docs), the FAQ (https://example.com/faq) and docs (https://example.com/docs)\nhttps://example.com/raw\n" +
+				"See the guide (https://example.com/guide).\n",
+		},
+		{
+			name:  "collected at the end",
+			links: TextLinksEnd,
+			want: "Read the docs [1], the FAQ [2] and docs [1]\nhttps://example.com/raw\nSee the guide [3].\n" +
+				"\nLinks:\n[1] https://example.com/docs\n[2] https://example.com/faq\n[3] https://example.com/guide\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := TextRenderer{Links: tt.links}.Render(&Document{Blocks: blocks})
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Render() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEstimateTokens(t *testing.T) {
+	tests := []struct {
+		text string
+		want int
+	}{
+		{"", 0},
+		{"abc", 1},
+		{"abcd", 1},
+		{"Hello, world!", 4},
+		{"日本語です", 2},
+	}
+	for _, tt := range tests {
+		if got := EstimateTokens(tt.text); got != tt.want {
+			t.Errorf("EstimateTokens(%q) = %d, want %d", tt.text, got, tt.want)
+		}
+	}
+}
@@ -24,9 +24,39 @@ func NewClient(ctx context.Context, httpClient *http.Client) (*Client, error) {
 	return &Client{service: service}, nil
 }
 
+// Suggestions modes accepted by ParseSuggestionsMode.
+const (
+	SuggestionsInline    = "inline"
+	SuggestionsAcceptAll = "accept-all"
+	SuggestionsRejectAll = "reject-all"
+)
+
+// ParseSuggestionsMode maps a suggestions mode (inline, accept-all or
+// reject-all) to the API's SuggestionsViewMode. An empty mode maps to an
+// empty view mode, which uses the default for the user's access level.
+func ParseSuggestionsMode(mode string) (string, error) {
+	switch mode {
+	case "":
+		return "", nil
+	case SuggestionsInline:
+		return "SUGGESTIONS_INLINE", nil
+	case SuggestionsAcceptAll:
+		return "PREVIEW_SUGGESTIONS_ACCEPTED", nil
+	case SuggestionsRejectAll:
+		return "PREVIEW_WITHOUT_SUGGESTIONS", nil
+	}
+	return "", fmt.Errorf("invalid suggestions mode '%s': expected inline, accept-all or reject-all", mode)
+}
+
 // FetchDocument retrieves a Google Docs document by its ID with all tabs included.
-func (c *Client) FetchDocument(docID string) (*docs.Document, error) {
-	doc, err := c.service.Documents.Get(docID).IncludeTabsContent(true).Do()
+// viewMode is the API's SuggestionsViewMode; if empty, the default for the
+// user's access level is used.
+func (c *Client) FetchDocument(docID string, viewMode string) (*docs.Document, error) {
+	call := c.service.Documents.Get(docID).IncludeTabsContent(true)
+	if viewMode != "" {
+		call = call.SuggestionsViewMode(viewMode)
+	}
+	doc, err := call.Do()
 	if err != nil {
 		return nil, fmt.Errorf("unable to retrieve document: %w\n\nThis could mean:\n1. The document is private and you don't have permission\n2. The document doesn't exist\n3. The document ID is incorrect", err)
 	}
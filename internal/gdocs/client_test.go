@@ -182,3 +182,29 @@ func TestGetFirstTab(t *testing.T) {
 		})
 	}
 }
+
+func TestParseSuggestionsMode(t *testing.T) {
+	tests := []struct {
+		mode    string
+		want    string
+		wantErr bool
+	}{
+		{mode: "", want: ""},
+		{mode: "inline", want: "SUGGESTIONS_INLINE"},
+		{mode: "accept-all", want: "PREVIEW_SUGGESTIONS_ACCEPTED"},
+		{mode: "reject-all", want: "PREVIEW_WITHOUT_SUGGESTIONS"},
+		{mode: "accept", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			got, err := ParseSuggestionsMode(tt.mode)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseSuggestionsMode(%q) error = %v, wantErr %v", tt.mode, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseSuggestionsMode(%q) = %q, want %q", tt.mode, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package gdocs
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// FetchImage downloads an image from a content URI returned by the Docs API
+// using the authenticated HTTP client. It returns the image bytes and the
+// content type reported by the server.
+func FetchImage(ctx context.Context, httpClient *http.Client, uri string) ([]byte, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid image URI: %w", err)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("unable to download image: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("unable to download image: unexpected status %s", resp.Status)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", fmt.Errorf("unable to read image: %w", err)
+	}
+
+	return data, resp.Header.Get("Content-Type"), nil
+}
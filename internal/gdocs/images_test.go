@@ -0,0 +1,63 @@
+package gdocs
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFetchImage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/image" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "image/png")
+		w.Write([]byte("png-data"))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name            string
+		uri             string
+		wantData        string
+		wantContentType string
+		wantErr         bool
+	}{
+		{
+			name:            "successful download",
+			uri:             server.URL + "/image",
+			wantData:        "png-data",
+			wantContentType: "image/png",
+		},
+		{
+			name:    "not found",
+			uri:     server.URL + "/missing",
+			wantErr: true,
+		},
+		{
+			name:    "invalid URI",
+			uri:     "://bad",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, contentType, err := FetchImage(context.Background(), server.Client(), tt.uri)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FetchImage() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if string(data) != tt.wantData {
+				t.Errorf("FetchImage() data = %q, want %q", data, tt.wantData)
+			}
+			if contentType != tt.wantContentType {
+				t.Errorf("FetchImage() contentType = %q, want %q", contentType, tt.wantContentType)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package markdown
+
+import (
+	"net/url"
+	"strings"
+
+	"google.golang.org/api/docs/v1"
+)
+
+// docsURL is the base URL of a document in Google Docs.
+const docsURL = "https://docs.google.com/document/d/"
+
+// paragraphHeading returns the heading entry of a heading paragraph.
+func (c *Converter) paragraphHeading(paragraph *docs.Paragraph) (heading, bool) {
+	for _, h := range c.documentHeadings() {
+		if h.paragraph == paragraph {
+			return h, true
+		}
+	}
+	return heading{}, false
+}
+
+// headingAnchor returns the anchor of the heading with the given Docs
+// heading ID.
+func (c *Converter) headingAnchor(id string) (string, bool) {
+	if id == "" {
+		return "", false
+	}
+	for _, h := range c.documentHeadings() {
+		if h.id == id {
+			return h.anchor, true
+		}
+	}
+	return "", false
+}
+
+// linkURL returns the target of a link. Links to headings in the converted
+// tab point to the heading's anchor. Other links within the document, such
+// as links to bookmarks (whose positions the API does not return) or to
+// other tabs, point to the document in Google Docs instead of being left
+// as dead relative URLs.
+func (c *Converter) linkURL(link *docs.Link) string {
+	if link == nil {
+		return ""
+	}
+
+	headingID, bookmarkID, tabID := link.HeadingId, link.BookmarkId, link.TabId
+	if link.Heading != nil {
+		headingID, tabID = link.Heading.Id, link.Heading.TabId
+	}
+	if link.Bookmark != nil {
+		bookmarkID, tabID = link.Bookmark.Id, link.Bookmark.TabId
+	}
+	// Docs writes older intra-document links as bare URL fragments
+	if headingID == "" && bookmarkID == "" && strings.HasPrefix(link.Url, "#") {
+		headingID, bookmarkID = parseDocsFragment(link.Url)
+	}
+
+	if headingID != "" && (tabID == "" || tabID == c.tabID) {
+		if anchor, ok := c.headingAnchor(headingID); ok {
+			return "#" + anchor
+		}
+	}
+
+	if link.Url != "" && !strings.HasPrefix(link.Url, "#") {
+		return c.rewriteDocumentLink(link.Url)
+	}
+	if headingID == "" && bookmarkID == "" && tabID == "" {
+		return link.Url
+	}
+	if c.doc == nil || c.doc.DocumentId == "" {
+		return link.Url
+	}
+
+	target := docsURL + c.doc.DocumentId + "/edit"
+	if tabID != "" {
+		target += "?tab=" + url.QueryEscape(tabID)
+	}
+	if headingID != "" {
+		target += "#heading=" + headingID
+	} else if bookmarkID != "" {
+		target += "#bookmark=" + bookmarkID
+	}
+	return target
+}
+
+// parseDocsFragment returns the heading or bookmark ID of a Docs link
+// fragment such as #heading=h.abc123 or #bookmark=id.xyz.
+func parseDocsFragment(fragment string) (headingID, bookmarkID string) {
+	key, value, ok := strings.Cut(strings.TrimPrefix(fragment, "#"), "=")
+	if !ok {
+		return "", ""
+	}
+	switch key {
+	case "heading":
+		return value, ""
+	case "bookmark":
+		return "", value
+	}
+	return "", ""
+}
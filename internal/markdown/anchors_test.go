@@ -0,0 +1,113 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+
+	"google.golang.org/api/docs/v1"
+)
+
+func linkedParagraph(text string, link *docs.Link) *docs.StructuralElement {
+	return &docs.StructuralElement{Paragraph: &docs.Paragraph{Elements: []*docs.ParagraphElement{
+		{TextRun: &docs.TextRun{Content: text, TextStyle: &docs.TextStyle{Link: link}}},
+		{TextRun: &docs.TextRun{Content: "\n"}},
+	}}}
+}
+
+func TestHeadingLinks(t *testing.T) {
+	overview := headingParagraph("HEADING_1", "Overview")
+	overview.Paragraph.ParagraphStyle.HeadingId = "h.abc123"
+	goals := headingParagraph("HEADING_2", "Overview")
+	goals.Paragraph.ParagraphStyle.HeadingId = "h.def456"
+
+	tests := []struct {
+		name string
+		link *docs.Link
+		want string
+	}{
+		{
+			name: "heading ID",
+			link: &docs.Link{HeadingId: "h.abc123"},
+			want: "[see](#overview)",
+		},
+		{
+			name: "heading in the same tab",
+			link: &docs.Link{Heading: &docs.HeadingLink{Id: "h.def456", TabId: "t.0"}},
+			want: "[see](#overview-1)",
+		},
+		{
+			name: "heading fragment",
+			link: &docs.Link{Url: "#heading=h.def456"},
+			want: "[see](#overview-1)",
+		},
+		{
+			name: "heading in another tab",
+			link: &docs.Link{Heading: &docs.HeadingLink{Id: "h.xyz", TabId: "t.1"}},
+			want: "[see](https://docs.google.com/document/d/doc1/edit?tab=t.1#heading=h.xyz)",
+		},
+		{
+			name: "bookmark",
+			link: &docs.Link{Url: "#bookmark=id.q1"},
+			want: "[see](https://docs.google.com/document/d/doc1/edit#bookmark=id.q1)",
+		},
+		{
+			name: "external link",
+			link: &docs.Link{Url: "https://example.com/#heading=h.abc123"},
+			want: "[see](https://example.com/#heading=h.abc123)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := &docs.Document{
+				DocumentId: "doc1",
+				Tabs: []*docs.Tab{{
+					TabProperties: &docs.TabProperties{TabId: "t.0"},
+					DocumentTab: &docs.DocumentTab{Body: &docs.Body{Content: []*docs.StructuralElement{
+						overview, goals, linkedParagraph("see", tt.link),
+					}}},
+				}},
+			}
+			want := "# Overview\n\n## Overview\n\n" + tt.want + "\n\n"
+			if got := convertBody(t, NewConverter(doc)); got != want {
+				t.Errorf("convertBody() = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestExplicitHeadingAnchors(t *testing.T) {
+	doc := &docs.Document{Body: &docs.Body{Content: []*docs.StructuralElement{
+		headingParagraph("TITLE", "Design"),
+		headingParagraph("HEADING_1", "Design"),
+		{Paragraph: &docs.Paragraph{Elements: []*docs.ParagraphElement{{TextRun: &docs.TextRun{Content: "Text\n"}}}}},
+	}}}
+
+	c := NewConverter(doc)
+	c.SetOptions(Options{HeadingAnchors: AnchorsExplicit})
+	want := "# Design {#design}\n\n# Design {#design-1}\n\nText\n\n"
+	if got := convertBody(t, c); got != want {
+		t.Errorf("convertBody() = %q, want %q", got, want)
+	}
+}
+
+func TestHeadingWithPageBreak(t *testing.T) {
+	intro := headingParagraph("HEADING_1", "Intro")
+	intro.Paragraph.ParagraphStyle.HeadingId = "h.intro"
+	intro.Paragraph.Elements = []*docs.ParagraphElement{
+		{TextRun: &docs.TextRun{Content: "Intro"}},
+		{PageBreak: &docs.PageBreak{}},
+		{TextRun: &docs.TextRun{Content: "\n"}},
+	}
+	doc := &docs.Document{Body: &docs.Body{Content: []*docs.StructuralElement{
+		intro,
+		linkedParagraph("see", &docs.Link{HeadingId: "h.intro"}),
+	}}}
+
+	c := NewConverter(doc)
+	c.SetOptions(Options{Flavor: FlavorPandoc, NumberHeadings: true})
+	want := "# 1 Intro {#1-intro}\n\n"
+	if got := convertBody(t, c); !strings.HasPrefix(got, want) || !strings.Contains(got, "[see](#1-intro)") {
+		t.Errorf("convertBody() = %q, want heading %q and a link to it", got, want)
+	}
+}
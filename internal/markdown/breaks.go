@@ -0,0 +1,102 @@
+package markdown
+
+import (
+	"strings"
+
+	"github.com/famasya/gdocs-cli/internal/document"
+	"google.golang.org/api/docs/v1"
+)
+
+// buildParagraphBlocks builds a paragraph, with horizontal rules and page
+// breaks inside it as separate blocks, followed by its positioned images.
+// Text around a break keeps the paragraph's style.
+func (c *Converter) buildParagraphBlocks(paragraph *docs.Paragraph) []document.Block {
+	var blocks []document.Block
+	var segment []*docs.ParagraphElement
+	hasBreak, headed := false, false
+	flush := func() {
+		if !isBlankSegment(segment) {
+			if block, ok := c.buildParagraphPart(paragraph, segment, paragraph.ParagraphStyle); ok {
+				// A heading split by a break is anchored and numbered
+				// at its first part
+				if block.Heading != nil && headed {
+					block.Heading.Anchor, block.Heading.ID, block.Heading.Number = "", "", ""
+				}
+				headed = headed || block.Heading != nil
+				blocks = append(blocks, block)
+			}
+		}
+		segment = nil
+	}
+
+	for _, element := range paragraph.Elements {
+		switch {
+		case element.HorizontalRule != nil:
+			hasBreak = true
+			flush()
+			blocks = c.appendBreak(blocks, c.opts.HorizontalRules, document.HorizontalRule)
+		case element.PageBreak != nil:
+			hasBreak = true
+			flush()
+			blocks = c.appendBreak(blocks, c.opts.PageBreaks, document.PageBreak)
+		default:
+			segment = append(segment, element)
+		}
+	}
+	if !hasBreak {
+		if block, ok := c.buildParagraph(paragraph, paragraph.ParagraphStyle); ok {
+			blocks = append(blocks, block)
+		}
+	} else {
+		flush()
+	}
+
+	return append(blocks, c.positionedImages(paragraph.PositionedObjectIds)...)
+}
+
+// appendBreak appends a break block unless breaks of its kind are left
+// out.
+func (c *Converter) appendBreak(blocks []document.Block, mode BreakMode, kind string) []document.Block {
+	if mode == BreakNone {
+		return blocks
+	}
+	return append(blocks, document.Block{Break: &document.Break{Kind: kind}})
+}
+
+// buildSectionBreak builds a section break. Only sections that start on a
+// new page are kept, as page breaks.
+func (c *Converter) buildSectionBreak(sectionBreak *docs.SectionBreak) *document.Break {
+	if sectionBreak.SectionStyle == nil || sectionBreak.SectionStyle.SectionType != "NEXT_PAGE" {
+		return nil
+	}
+	if c.opts.PageBreaks == BreakNone {
+		return nil
+	}
+	return &document.Break{Kind: document.PageBreak}
+}
+
+// renderBreak renders a break block according to the options.
+func (r *Renderer) renderBreak(b *document.Break) string {
+	mode, html := r.opts.HorizontalRules, "<hr>"
+	if b.Kind == document.PageBreak {
+		mode, html = r.opts.PageBreaks, `<div style="page-break-after: always"></div>`
+	}
+	switch mode {
+	case BreakNone:
+		return ""
+	case BreakHTML:
+		return html + "\n\n"
+	}
+	return "---\n\n"
+}
+
+// isBlankSegment reports whether paragraph elements contain nothing but
+// whitespace text.
+func isBlankSegment(elements []*docs.ParagraphElement) bool {
+	for _, element := range elements {
+		if element.TextRun == nil || strings.TrimSpace(element.TextRun.Content) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,60 @@
+package markdown
+
+import (
+	"testing"
+
+	"google.golang.org/api/docs/v1"
+)
+
+func TestConvertBreaks(t *testing.T) {
+	content := []*docs.StructuralElement{
+		{SectionBreak: &docs.SectionBreak{SectionStyle: &docs.SectionStyle{SectionType: "CONTINUOUS"}}},
+		{Paragraph: &docs.Paragraph{Elements: []*docs.ParagraphElement{
+			{TextRun: &docs.TextRun{Content: "Intro"}},
+			{PageBreak: &docs.PageBreak{}},
+			{TextRun: &docs.TextRun{Content: "\n"}},
+		}}},
+		{Paragraph: &docs.Paragraph{Elements: []*docs.ParagraphElement{
+			{HorizontalRule: &docs.HorizontalRule{}},
+			{TextRun: &docs.TextRun{Content: "\n"}},
+		}}},
+		{Paragraph: &docs.Paragraph{Elements: []*docs.ParagraphElement{
+			{TextRun: &docs.TextRun{Content: "Appendix\n"}},
+		}}},
+		{SectionBreak: &docs.SectionBreak{SectionStyle: &docs.SectionStyle{SectionType: "NEXT_PAGE"}}},
+		{Paragraph: &docs.Paragraph{Elements: []*docs.ParagraphElement{
+			{TextRun: &docs.TextRun{Content: "Landscape\n"}},
+		}}},
+	}
+
+	tests := []struct {
+		name string
+		opts Options
+		want string
+	}{
+		{
+			name: "rules by default",
+			want: "Intro\n\n---\n\n---\n\nAppendix\n\n---\n\nLandscape\n\n",
+		},
+		{
+			name: "html",
+			opts: Options{HorizontalRules: BreakHTML, PageBreaks: BreakHTML},
+			want: "Intro\n\n<div style=\"page-break-after: always\"></div>\n\n<hr>\n\nAppendix\n\n" +
+				"<div style=\"page-break-after: always\"></div>\n\nLandscape\n\n",
+		},
+		{
+			name: "page breaks dropped",
+			opts: Options{PageBreaks: BreakNone},
+			want: "Intro\n\n---\n\nAppendix\n\nLandscape\n\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Converter{opts: tt.opts}
+			if got := convertContent(t, c, content); got != tt.want {
+				t.Errorf("convertContent() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
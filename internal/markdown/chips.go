@@ -0,0 +1,105 @@
+package markdown
+
+import (
+	"strings"
+	"time"
+
+	"github.com/famasya/gdocs-cli/internal/document"
+	"google.golang.org/api/docs/v1"
+)
+
+// Mention is a person mentioned in the document with a person chip.
+type Mention = document.Mention
+
+// personMention builds a mention from a person chip.
+func personMention(person *docs.Person) *document.Mention {
+	props := person.PersonProperties
+	if props == nil || (props.Name == "" && props.Email == "") {
+		return nil
+	}
+	return &document.Mention{Name: props.Name, Email: props.Email}
+}
+
+// renderMention renders a mention as the person's name, linked to their
+// email address unless plain mentions are requested.
+func (r *Renderer) renderMention(mention *document.Mention) string {
+	name := mention.Name
+	if name == "" {
+		name = mention.Email
+	}
+	if !r.opts.RawText {
+		name = escapeText(name)
+	}
+	if mention.Email == "" || r.opts.PlainMentions {
+		return name
+	}
+	return formatLink(name, "mailto:"+mention.Email)
+}
+
+// richLinkText builds a rich link chip as linked text titled by the linked
+// page.
+func (c *Converter) richLinkText(link *docs.RichLink) *document.Text {
+	props := link.RichLinkProperties
+	if props == nil || props.Uri == "" {
+		return nil
+	}
+
+	title := props.Title
+	if title == "" {
+		title = props.Uri
+	}
+	return &document.Text{Value: title, TextStyle: document.TextStyle{Link: c.linkURL(&docs.Link{Url: props.Uri})}}
+}
+
+// convertDate converts a date chip to an ISO 8601 date, including the
+// time if the chip shows one. The date and time are those in the chip's
+// time zone, or in UTC if it has none.
+func convertDate(date *docs.DateElement) string {
+	props := date.DateElementProperties
+	if props == nil {
+		return ""
+	}
+
+	t, err := time.Parse(time.RFC3339, props.Timestamp)
+	if err != nil {
+		return props.DisplayText
+	}
+
+	t = t.UTC()
+	if props.TimeZoneId != "" {
+		if loc, err := time.LoadLocation(props.TimeZoneId); err == nil {
+			t = t.In(loc)
+		}
+	}
+	if props.TimeFormat == "" || props.TimeFormat == "TIME_FORMAT_DISABLED" {
+		return t.Format("2006-01-02")
+	}
+	return t.Format("2006-01-02T15:04Z07:00")
+}
+
+// collectMentions returns the people mentioned in the content, in order of
+// first mention.
+func collectMentions(content []*docs.StructuralElement) []Mention {
+	var mentions []Mention
+	seen := make(map[string]bool)
+
+	walkParagraphs(content, func(paragraph *docs.Paragraph) {
+		for _, element := range paragraph.Elements {
+			if element.Person == nil || element.Person.PersonProperties == nil {
+				continue
+			}
+			props := element.Person.PersonProperties
+			key := strings.ToLower(props.Email)
+			if key == "" {
+				key = props.Name
+			}
+			if key == "" || seen[key] {
+				continue
+			}
+			seen[key] = true
+			mentions = append(mentions, Mention{Name: props.Name, Email: props.Email})
+		}
+	})
+
+	return mentions
+}
@@ -0,0 +1,149 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+
+	"google.golang.org/api/docs/v1"
+)
+
+func person(name, email string) *docs.ParagraphElement {
+	return &docs.ParagraphElement{Person: &docs.Person{PersonProperties: &docs.PersonProperties{Name: name, Email: email}}}
+}
+
+func TestConvertChips(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     Options
+		elements []*docs.ParagraphElement
+		want     string
+	}{
+		{
+			name: "person with mailto link",
+			elements: []*docs.ParagraphElement{
+				{TextRun: &docs.TextRun{Content: "Owner: "}},
+				person("Ada Lovelace", "ada@example.com"),
+			},
+			want: "Owner: [Ada Lovelace](mailto:ada@example.com)",
+		},
+		{
+			name: "plain mentions",
+			opts: Options{PlainMentions: true},
+			elements: []*docs.ParagraphElement{
+				person("Ada Lovelace", "ada@example.com"),
+			},
+			want: "Ada Lovelace",
+		},
+		{
+			name: "person without name",
+			elements: []*docs.ParagraphElement{
+				person("", "ops@example.com"),
+			},
+			want: "[ops@example.com](mailto:ops@example.com)",
+		},
+		{
+			name: "rich link",
+			elements: []*docs.ParagraphElement{
+				{RichLink: &docs.RichLink{RichLinkProperties: &docs.RichLinkProperties{
+					Title: "Design [v2]",
+					Uri:   "https://docs.google.com/document/d/abc/edit",
+				}}},
+			},
+			want: "[Design \\[v2\\]](https://docs.google.com/document/d/abc/edit)",
+		},
+		{
+			name: "rich link without title",
+			elements: []*docs.ParagraphElement{
+				{RichLink: &docs.RichLink{RichLinkProperties: &docs.RichLinkProperties{Uri: "https://example.com"}}},
+			},
+			want: "[https://example.com](https://example.com)",
+		},
+		{
+			name: "date chip",
+			elements: []*docs.ParagraphElement{
+				{TextRun: &docs.TextRun{Content: "Due "}},
+				{DateElement: &docs.DateElement{DateElementProperties: &docs.DateElementProperties{
+					Timestamp:   "2025-03-14T00:00:00Z",
+					DisplayText: "Mar 14, 2025",
+					TimeFormat:  "TIME_FORMAT_DISABLED",
+				}}},
+			},
+			want: "Due 2025-03-14",
+		},
+		{
+			name: "date chip with time",
+			elements: []*docs.ParagraphElement{
+				{DateElement: &docs.DateElement{DateElementProperties: &docs.DateElementProperties{
+					Timestamp:  "2025-03-14T15:30:00Z",
+					TimeFormat: "TIME_FORMAT_HOUR_MINUTE",
+					TimeZoneId: "UTC",
+				}}},
+			},
+			want: "2025-03-14T15:30Z",
+		},
+		{
+			name: "date chip in a time zone ahead of UTC",
+			elements: []*docs.ParagraphElement{
+				{DateElement: &docs.DateElement{DateElementProperties: &docs.DateElementProperties{
+					Timestamp:  "2025-03-13T15:00:00Z",
+					TimeFormat: "TIME_FORMAT_DISABLED",
+					TimeZoneId: "Asia/Tokyo",
+				}}},
+			},
+			want: "2025-03-14",
+		},
+		{
+			name: "date chip with time in a time zone",
+			elements: []*docs.ParagraphElement{
+				{DateElement: &docs.DateElement{DateElementProperties: &docs.DateElementProperties{
+					Timestamp:  "2025-03-13T15:00:00Z",
+					TimeFormat: "TIME_FORMAT_HOUR_MINUTE",
+					TimeZoneId: "Asia/Tokyo",
+				}}},
+			},
+			want: "2025-03-14T00:00+09:00",
+		},
+		{
+			name: "date chip without timestamp",
+			elements: []*docs.ParagraphElement{
+				{DateElement: &docs.DateElement{DateElementProperties: &docs.DateElementProperties{DisplayText: "Tomorrow"}}},
+			},
+			want: "Tomorrow",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Converter{opts: tt.opts}
+			if got := convertElements(t, c, tt.elements); got != tt.want {
+				t.Errorf("convertElements() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMentionsFrontmatter(t *testing.T) {
+	doc := &docs.Document{
+		Title: "Plan",
+		Body: &docs.Body{Content: []*docs.StructuralElement{
+			{Paragraph: &docs.Paragraph{Elements: []*docs.ParagraphElement{
+				person("Ada Lovelace", "ada@example.com"),
+				{TextRun: &docs.TextRun{Content: " and "}},
+				person("Ada L.", "ADA@example.com"),
+				{TextRun: &docs.TextRun{Content: "\n"}},
+			}}},
+			{Table: tableOf([]*docs.TableCell{{Content: []*docs.StructuralElement{
+				{Paragraph: &docs.Paragraph{Elements: []*docs.ParagraphElement{person("Grace Hopper", "grace@example.com")}}},
+			}}})},
+		}},
+	}
+
+	got, err := NewConverter(doc).Convert()
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	want := "---\ntitle: Plan\nmentions:\n    - name: Ada Lovelace\n      email: ada@example.com\n    - name: Grace Hopper\n      email: grace@example.com\n---\n"
+	if !strings.HasPrefix(got, want) {
+		t.Errorf("Convert() = %q, want frontmatter %q", got, want)
+	}
+}
@@ -0,0 +1,213 @@
+package markdown
+
+import (
+	"net/url"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/famasya/gdocs-cli/internal/document"
+)
+
+// Chunk is a part of a converted document, split at headings for
+// indexing in retrieval pipelines.
+type Chunk struct {
+	// Text is the chunk's markdown.
+	Text string `json:"text"`
+	// HeadingPath lists the headings the chunk is under, outermost
+	// first. It is empty before the first heading and for content after
+	// the body, such as footnotes and comments.
+	HeadingPath []string `json:"heading_path"`
+	DocumentID  string   `json:"document_id"`
+	TabID       string   `json:"tab_id,omitempty"`
+	// Link opens the chunk's heading in Google Docs, or the document if
+	// the heading has no ID.
+	Link string `json:"link,omitempty"`
+	// Start and End are character offsets of Text in the markdown of
+	// the whole document.
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// Chunks converts the document to markdown and splits it into chunks.
+// See Renderer.Chunks.
+func (c *Converter) Chunks(maxSize int) ([]Chunk, error) {
+	doc, err := c.Document()
+	if err != nil {
+		return nil, err
+	}
+	return NewRenderer(c.opts).Chunks(doc, maxSize)
+}
+
+// Chunks renders a document as markdown and splits the output at heading
+// boundaries. Sections longer than maxSize characters are split between
+// blocks, and blocks longer than that between lines or words. A maxSize of
+// zero or less splits at headings only. The frontmatter and page headers
+// and footers are left out. Chunk offsets point into the output of
+// Render.
+func (r *Renderer) Chunks(doc *document.Document, maxSize int) ([]Chunk, error) {
+	output, err := r.Render(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	// Find where the body starts, as Render writes it
+	frontmatter, err := renderFrontmatter(doc.Metadata)
+	if err != nil {
+		return nil, err
+	}
+	pos := len(frontmatter) + len("\n") + len(r.renderSections(doc.Headers))
+
+	c := &chunker{output: output, maxSize: maxSize, documentID: doc.DocumentID, tabID: doc.TabID}
+	c.link = c.deepLink("")
+
+	type pathHeading struct {
+		level int
+		text  string
+	}
+	var path []pathHeading
+	for i, part := range r.renderBlockParts(doc.Blocks) {
+		if heading := doc.Blocks[i].Heading; heading != nil {
+			c.flush()
+			for len(path) > 0 && path[len(path)-1].level >= heading.Level {
+				path = path[:len(path)-1]
+			}
+			path = append(path, pathHeading{level: heading.Level, text: headingText(heading)})
+			c.path = make([]string, len(path))
+			for j, h := range path {
+				c.path[j] = h.text
+			}
+			c.link = c.deepLink(heading.ID)
+		}
+		c.add(pos, pos+len(part))
+		pos += len(part)
+	}
+	c.flush()
+
+	// Footnotes and comments follow the body, after the page footers
+	c.path, c.link = nil, c.deepLink("")
+	c.add(pos+len(r.renderSections(doc.Footers)), len(output))
+	c.flush()
+
+	return c.chunks, nil
+}
+
+// chunker collects consecutive blocks of the output into chunks.
+type chunker struct {
+	output            string
+	maxSize           int
+	documentID, tabID string
+	chunks            []Chunk
+	path              []string
+	link              string
+	pending           bool
+	start, end        int // byte offsets of the pending chunk
+	countedTo, runes  int // characters before byte offset countedTo
+}
+
+// add adds the output between the byte offsets start and end to the
+// pending chunk, starting a new chunk if it would grow too large.
+func (c *chunker) add(start, end int) {
+	if start >= end {
+		return
+	}
+	if c.pending && c.fits(c.start, end) {
+		c.end = end
+		return
+	}
+	c.flush()
+	if c.fits(start, end) {
+		c.pending, c.start, c.end = true, start, end
+		return
+	}
+
+	// Split a block that is too large on its own
+	for start < end {
+		cut := c.cut(start, end)
+		c.pending, c.start, c.end = true, start, cut
+		c.flush()
+		start = cut
+	}
+}
+
+// fits reports whether the output between two byte offsets fits in a
+// chunk.
+func (c *chunker) fits(start, end int) bool {
+	return c.maxSize <= 0 || utf8.RuneCountInString(c.output[start:end]) <= c.maxSize
+}
+
+// cut returns where to end a chunk of at most maxSize characters starting
+// at start: after the last line break or space that fits, or else at the
+// size limit.
+func (c *chunker) cut(start, end int) int {
+	limit := start
+	for n := 0; n < c.maxSize && limit < end; n++ {
+		_, size := utf8.DecodeRuneInString(c.output[limit:])
+		limit += size
+	}
+	if limit == end {
+		return end
+	}
+	text := c.output[start:limit]
+	if i := strings.LastIndexByte(text, '\n'); i > 0 {
+		return start + i + 1
+	}
+	if i := strings.LastIndexByte(text, ' '); i > 0 {
+		return start + i + 1
+	}
+	return limit
+}
+
+// flush ends the pending chunk, trimming the whitespace around it.
+func (c *chunker) flush() {
+	if !c.pending {
+		return
+	}
+	c.pending = false
+
+	text := c.output[c.start:c.end]
+	trimmed := strings.TrimLeftFunc(text, unicode.IsSpace)
+	start := c.start + len(text) - len(trimmed)
+	trimmed = strings.TrimRightFunc(trimmed, unicode.IsSpace)
+	if trimmed == "" {
+		return
+	}
+
+	chunk := Chunk{
+		Text:        trimmed,
+		HeadingPath: c.path,
+		DocumentID:  c.documentID,
+		TabID:       c.tabID,
+		Link:        c.link,
+		Start:       c.offset(start),
+	}
+	chunk.End = chunk.Start + utf8.RuneCountInString(trimmed)
+	if chunk.HeadingPath == nil {
+		chunk.HeadingPath = []string{}
+	}
+	c.chunks = append(c.chunks, chunk)
+}
+
+// offset converts a byte offset into the output to a character offset.
+// Offsets are converted in increasing order.
+func (c *chunker) offset(pos int) int {
+	c.runes += utf8.RuneCountInString(c.output[c.countedTo:pos])
+	c.countedTo = pos
+	return c.runes
+}
+
+// deepLink returns the URL of a heading in Google Docs, or of the
+// document if headingID is empty.
+func (c *chunker) deepLink(headingID string) string {
+	if c.documentID == "" {
+		return ""
+	}
+	link := docsURL + c.documentID + "/edit"
+	if c.tabID != "" {
+		link += "?tab=" + url.QueryEscape(c.tabID)
+	}
+	if headingID != "" {
+		link += "#heading=" + headingID
+	}
+	return link
+}
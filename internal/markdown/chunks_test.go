@@ -0,0 +1,96 @@
+package markdown
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/docs/v1"
+)
+
+func TestChunks(t *testing.T) {
+	heading := func(style, text, id string) *docs.StructuralElement {
+		h := headingParagraph(style, text)
+		h.Paragraph.ParagraphStyle.HeadingId = id
+		return h
+	}
+	paragraph := func(text string) *docs.StructuralElement {
+		return &docs.StructuralElement{Paragraph: &docs.Paragraph{
+			Elements: []*docs.ParagraphElement{{TextRun: &docs.TextRun{Content: text + "\n"}}},
+		}}
+	}
+
+	doc := &docs.Document{
+		DocumentId: "doc1",
+		Title:      "Plan",
+		Tabs: []*docs.Tab{{
+			TabProperties: &docs.TabProperties{TabId: "t.0"},
+			DocumentTab: &docs.DocumentTab{Body: &docs.Body{Content: []*docs.StructuralElement{
+				paragraph("Draft for review."),
+				heading("HEADING_1", "Café goals", "h.goals"),
+				paragraph("Ship the beta."),
+				heading("HEADING_2", "Risks", "h.risks"),
+				paragraph("Hiring is slow and the vendor contract ends in June."),
+				heading("HEADING_1", "Budget", ""),
+				paragraph("Flat."),
+			}}},
+		}},
+	}
+
+	chunks, err := NewConverter(doc).Chunks(40)
+	if err != nil {
+		t.Fatalf("Chunks() error = %v", err)
+	}
+
+	link := "https://docs.google.com/document/d/doc1/edit?tab=t.0"
+	want := []struct {
+		text string
+		path []string
+		link string
+	}{
+		{"Draft for review.", []string{}, link},
+		{"# Café goals\n\nShip the beta.", []string{"Café goals"}, link + "#heading=h.goals"},
+		{"## Risks", []string{"Café goals", "Risks"}, link + "#heading=h.risks"},
+		{"Hiring is slow and the vendor contract", []string{"Café goals", "Risks"}, link + "#heading=h.risks"},
+		{"ends in June.", []string{"Café goals", "Risks"}, link + "#heading=h.risks"},
+		{"# Budget\n\nFlat.", []string{"Budget"}, link},
+	}
+	if len(chunks) != len(want) {
+		t.Fatalf("Chunks() returned %d chunks, want %d: %+v", len(chunks), len(want), chunks)
+	}
+
+	output, err := NewConverter(doc).Convert()
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	runes := []rune(output)
+	for i, chunk := range chunks {
+		if chunk.Text != want[i].text {
+			t.Errorf("chunk %d Text = %q, want %q", i, chunk.Text, want[i].text)
+		}
+		if !reflect.DeepEqual(chunk.HeadingPath, want[i].path) {
+			t.Errorf("chunk %d HeadingPath = %q, want %q", i, chunk.HeadingPath, want[i].path)
+		}
+		if chunk.Link != want[i].link {
+			t.Errorf("chunk %d Link = %q, want %q", i, chunk.Link, want[i].link)
+		}
+		if chunk.DocumentID != "doc1" || chunk.TabID != "t.0" {
+			t.Errorf("chunk %d DocumentID, TabID = %q, %q, want doc1, t.0", i, chunk.DocumentID, chunk.TabID)
+		}
+		if chunk.End > len(runes) || string(runes[chunk.Start:chunk.End]) != chunk.Text {
+			t.Errorf("chunk %d offsets [%d:%d] do not match its text in the output", i, chunk.Start, chunk.End)
+		}
+		if n := len([]rune(chunk.Text)); n > 40 {
+			t.Errorf("chunk %d has %d characters, want at most 40", i, n)
+		}
+	}
+
+	// Without a size limit, chunks only end at headings
+	chunks, err = NewConverter(doc).Chunks(0)
+	if err != nil {
+		t.Fatalf("Chunks() error = %v", err)
+	}
+	if len(chunks) != 4 || !strings.HasSuffix(chunks[2].Text, "ends in June.") {
+		t.Errorf("Chunks(0) = %+v, want 4 chunks split at headings", chunks)
+	}
+}
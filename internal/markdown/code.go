@@ -0,0 +1,172 @@
+package markdown
+
+import (
+	"strings"
+
+	"github.com/famasya/gdocs-cli/internal/document"
+	"google.golang.org/api/docs/v1"
+)
+
+// Google Docs wraps the content of a code block building block in these
+// private use characters.
+const (
+	codeBlockStartMarker = "\uEC03"
+	codeBlockEndMarker   = "\uEC02"
+)
+
+// monospaceFonts lists common monospace font families offered by Google Docs.
+var monospaceFonts = map[string]bool{
+	"consolas":        true,
+	"courier":         true,
+	"courier new":     true,
+	"cousine":         true,
+	"inconsolata":     true,
+	"menlo":           true,
+	"monaco":          true,
+	"monospace":       true,
+	"source code pro": true,
+	"ubuntu mono":     true,
+}
+
+// isMonospace reports whether a text style uses a monospace font.
+func isMonospace(style *docs.TextStyle) bool {
+	if style == nil || style.WeightedFontFamily == nil {
+		return false
+	}
+	family := strings.ToLower(style.WeightedFontFamily.FontFamily)
+	// Catch families such as Roboto Mono, JetBrains Mono or Fira Code
+	return monospaceFonts[family] || strings.Contains(family, " mono") || strings.HasSuffix(family, " code")
+}
+
+// formatCode wraps text in a code span, using a backtick fence longer than
+// any backtick run in the text. Trailing newlines are kept outside the span.
+func formatCode(text string) string {
+	trimmed := strings.TrimRight(text, "\n")
+	if strings.TrimSpace(trimmed) == "" {
+		return text
+	}
+	suffix := text[len(trimmed):]
+
+	fence := strings.Repeat("`", longestRun(trimmed, '`')+1)
+	// Pad with spaces so backticks at the edges are not part of the fence
+	if strings.HasPrefix(trimmed, "`") || strings.HasSuffix(trimmed, "`") {
+		trimmed = " " + trimmed + " "
+	}
+	return fence + trimmed + fence + suffix
+}
+
+// longestRun returns the length of the longest run of ch in s.
+func longestRun(s string, ch rune) int {
+	longest, current := 0, 0
+	for _, r := range s {
+		if r == ch {
+			current++
+			longest = max(longest, current)
+		} else {
+			current = 0
+		}
+	}
+	return longest
+}
+
+// codeBlockLength returns the number of leading elements that form a code
+// block, or 0 if content does not start with one. A code block is either a
+// Docs code block building block, or a run of paragraphs whose text is all
+// monospace. Blank paragraphs are only included between code paragraphs.
+func codeBlockLength(content []*docs.StructuralElement) int {
+	if len(content) == 0 || content[0].Paragraph == nil {
+		return 0
+	}
+
+	if strings.HasPrefix(paragraphText(content[0].Paragraph), codeBlockStartMarker) {
+		for i, element := range content {
+			if element.Paragraph == nil {
+				return i
+			}
+			if strings.Contains(paragraphText(element.Paragraph), codeBlockEndMarker) {
+				return i + 1
+			}
+		}
+		return len(content)
+	}
+
+	n := 0
+	for i, element := range content {
+		if element.Paragraph == nil {
+			break
+		}
+		if isCodeParagraph(element.Paragraph) {
+			n = i + 1
+		} else if strings.TrimSpace(paragraphText(element.Paragraph)) != "" {
+			break
+		}
+	}
+	return n
+}
+
+// isCodeParagraph reports whether a paragraph has text and all of its
+// non-blank text runs use a monospace font.
+func isCodeParagraph(paragraph *docs.Paragraph) bool {
+	if paragraph.Bullet != nil || isHeadingStyle(paragraph.ParagraphStyle) {
+		return false
+	}
+
+	hasText := false
+	for _, element := range paragraph.Elements {
+		if element.TextRun == nil {
+			if element.InlineObjectElement != nil || element.FootnoteReference != nil {
+				return false
+			}
+			continue
+		}
+		if strings.TrimSpace(element.TextRun.Content) == "" {
+			continue
+		}
+		if !isMonospace(element.TextRun.TextStyle) {
+			return false
+		}
+		hasText = true
+	}
+	return hasText
+}
+
+// isHeadingStyle reports whether a paragraph style is a title or heading.
+func isHeadingStyle(style *docs.ParagraphStyle) bool {
+	if style == nil {
+		return false
+	}
+	return style.NamedStyleType == "TITLE" || style.NamedStyleType == "SUBTITLE" ||
+		strings.HasPrefix(style.NamedStyleType, "HEADING_")
+}
+
+// paragraphText returns the raw text of a paragraph's text runs.
+func paragraphText(paragraph *docs.Paragraph) string {
+	var builder strings.Builder
+	for _, element := range paragraph.Elements {
+		if element.TextRun != nil {
+			builder.WriteString(element.TextRun.Content)
+		}
+	}
+	return builder.String()
+}
+
+// buildCodeBlock builds a code block from paragraphs. The text is kept
+// verbatim, without formatting.
+func (c *Converter) buildCodeBlock(content []*docs.StructuralElement) document.Block {
+	var lines []string
+	for _, element := range content {
+		text := paragraphText(element.Paragraph)
+		text = strings.NewReplacer(codeBlockStartMarker, "", codeBlockEndMarker, "", "\v", "\n").Replace(text)
+		lines = append(lines, strings.TrimSuffix(text, "\n"))
+	}
+	code := strings.Join(lines, "\n")
+	code = strings.Trim(code, "\n")
+
+	return document.Block{CodeBlock: &document.CodeBlock{Language: c.opts.CodeLanguage, Code: code}}
+}
+
+// renderCodeBlock renders a fenced code block, without escaping.
+func renderCodeBlock(block *document.CodeBlock) string {
+	fence := strings.Repeat("`", max(3, longestRun(block.Code, '`')+1))
+	return fence + block.Language + "\n" + block.Code + "\n" + fence + "\n\n"
+}
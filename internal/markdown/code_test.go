@@ -0,0 +1,136 @@
+package markdown
+
+import (
+	"testing"
+
+	"google.golang.org/api/docs/v1"
+)
+
+func mono(font string) *docs.TextStyle {
+	return &docs.TextStyle{WeightedFontFamily: &docs.WeightedFontFamily{FontFamily: font}}
+}
+
+func TestIsMonospace(t *testing.T) {
+	tests := []struct {
+		font string
+		want bool
+	}{
+		{"Courier New", true},
+		{"Roboto Mono", true},
+		{"Consolas", true},
+		{"Fira Code", true},
+		{"Arial", false},
+		{"Roboto", false},
+		{"Montserrat", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.font, func(t *testing.T) {
+			if got := isMonospace(mono(tt.font)); got != tt.want {
+				t.Errorf("isMonospace(%q) = %v, want %v", tt.font, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatCode(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"simple", "x := 1", "`x := 1`"},
+		{"asterisks", "a * b", "`a * b`"},
+		{"backticks inside", "use `go`", "`` use `go` ``"},
+		{"backtick at edge", "`", "`` ` ``"},
+		{"trailing newline", "main()\n", "`main()`\n"},
+		{"whitespace only", " ", " "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatCode(tt.text); got != tt.want {
+				t.Errorf("formatCode(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvertCodeBlocks(t *testing.T) {
+	para := func(runs ...*docs.TextRun) *docs.StructuralElement {
+		p := &docs.Paragraph{}
+		for _, run := range runs {
+			p.Elements = append(p.Elements, &docs.ParagraphElement{TextRun: run})
+		}
+		return &docs.StructuralElement{Paragraph: p}
+	}
+	code := func(text string) *docs.TextRun {
+		return &docs.TextRun{Content: text, TextStyle: mono("Courier New")}
+	}
+	plain := func(text string) *docs.TextRun {
+		return &docs.TextRun{Content: text}
+	}
+
+	tests := []struct {
+		name     string
+		language string
+		content  []*docs.StructuralElement
+		want     string
+	}{
+		{
+			name: "consecutive monospace paragraphs",
+			content: []*docs.StructuralElement{
+				para(plain("Run:\n")),
+				para(code("for i := 0; i < n; i++ {\n")),
+				para(code("    total *= i\n")),
+				para(code("}\n")),
+				para(plain("Done.\n")),
+			},
+			want: "Run:\n\n```\nfor i := 0; i < n; i++ {\n    total *= i\n}\n```\n\nDone.\n\n",
+		},
+		{
+			name:     "language hint and blank lines inside",
+			language: "go",
+			content: []*docs.StructuralElement{
+				para(code("a := 1\n")),
+				para(plain("\n")),
+				para(code("b := 2\n")),
+				para(plain("\n")),
+				para(plain("After\n")),
+			},
+			want: "```go\na := 1\n\nb := 2\n```\n\n\nAfter\n\n",
+		},
+		{
+			name: "inline code in prose",
+			content: []*docs.StructuralElement{
+				para(plain("Call "), code("init()"), plain(" first.\n")),
+			},
+			want: "Call `init()` first.\n\n",
+		},
+		{
+			name: "code block building block",
+			content: []*docs.StructuralElement{
+				para(plain("\uEC03SELECT *\n")),
+				para(plain("FROM t;\uEC02\n")),
+			},
+			want: "```\nSELECT *\nFROM t;\n```\n\n",
+		},
+		{
+			name: "fence longer than backticks in code",
+			content: []*docs.StructuralElement{
+				para(code("```\n")),
+			},
+			want: "````\n```\n````\n\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Converter{opts: Options{CodeLanguage: tt.language}}
+			got := convertContent(t, c, tt.content)
+			if got != tt.want {
+				t.Errorf("convertContent() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -5,11 +5,40 @@ import (
 	"strings"
 	"time"
 
+	"github.com/famasya/gdocs-cli/internal/document"
 	"github.com/famasya/gdocs-cli/internal/gdocs"
 )
 
 // ConvertComments renders a list of comments as a markdown section.
 func ConvertComments(comments []gdocs.Comment) string {
+	return renderComments(buildComments(comments))
+}
+
+// buildComments converts comments fetched from Drive.
+func buildComments(comments []gdocs.Comment) []document.Comment {
+	var result []document.Comment
+	for _, c := range comments {
+		comment := document.Comment{
+			Author:      c.Author,
+			Content:     c.Content,
+			QuotedText:  c.QuotedText,
+			CreatedTime: c.CreatedTime,
+			Resolved:    c.Resolved,
+		}
+		for _, r := range c.Replies {
+			comment.Replies = append(comment.Replies, document.Reply{
+				Author:      r.Author,
+				Content:     r.Content,
+				CreatedTime: r.CreatedTime,
+			})
+		}
+		result = append(result, comment)
+	}
+	return result
+}
+
+// renderComments renders comments as a markdown section.
+func renderComments(comments []document.Comment) string {
 	if len(comments) == 0 {
 		return ""
 	}
@@ -2,19 +2,44 @@ package markdown
 
 import (
 	"fmt"
-	"strings"
 
+	"github.com/famasya/gdocs-cli/internal/document"
 	"github.com/famasya/gdocs-cli/internal/gdocs"
 	"google.golang.org/api/docs/v1"
 )
 
 // Converter handles the conversion of Google Docs to markdown.
 type Converter struct {
-	doc      *docs.Document
-	body     *docs.Body
-	title    string
-	tabName  string
-	comments []gdocs.Comment
+	doc               *docs.Document
+	body              *docs.Body
+	lists             map[string]docs.List
+	inlineObjects     map[string]docs.InlineObject
+	positionedObjects map[string]docs.PositionedObject
+	footnotes         map[string]docs.Footnote
+	headers           map[string]docs.Header
+	footers           map[string]docs.Footer
+	documentStyle     *docs.DocumentStyle
+	title             string
+	tabName           string
+	tabID             string
+	comments          []gdocs.Comment
+	opts              Options
+
+	// listLevels tracks numbering per list ID and nesting level while
+	// the body is being converted.
+	listLevels map[string][]listLevel
+
+	// images maps embedded object IDs to the link target of their image.
+	images map[string]string
+
+	// footnoteIDs lists referenced footnotes in order of first reference;
+	// a footnote's number is its position in the list plus one.
+	footnoteIDs     []string
+	footnoteNumbers map[string]int
+
+	// headings lists the body's headings for the table of contents and
+	// heading links.
+	headings []heading
 }
 
 // NewConverter creates a new Converter for the given document.
@@ -26,13 +51,23 @@ func NewConverter(doc *docs.Document) *Converter {
 	if doc.Tabs != nil && len(doc.Tabs) > 0 {
 		tab := doc.Tabs[0]
 		if tab.DocumentTab != nil {
-			c.body = tab.DocumentTab.Body
+			c.setContent(tab.DocumentTab)
 		}
 		if tab.TabProperties != nil {
 			c.tabName = tab.TabProperties.Title
+			c.tabID = tab.TabProperties.TabId
 		}
 	} else if doc.Body != nil {
-		c.body = doc.Body
+		c.setContent(&docs.DocumentTab{
+			Body:              doc.Body,
+			Lists:             doc.Lists,
+			InlineObjects:     doc.InlineObjects,
+			PositionedObjects: doc.PositionedObjects,
+			Footnotes:         doc.Footnotes,
+			Headers:           doc.Headers,
+			Footers:           doc.Footers,
+			DocumentStyle:     doc.DocumentStyle,
+		})
 	}
 
 	return c
@@ -43,81 +78,178 @@ func NewConverterFromTab(doc *docs.Document, tab *docs.Tab) *Converter {
 	c := &Converter{doc: doc, title: doc.Title}
 
 	if tab != nil && tab.DocumentTab != nil {
-		c.body = tab.DocumentTab.Body
+		c.setContent(tab.DocumentTab)
 		if tab.TabProperties != nil {
 			c.tabName = tab.TabProperties.Title
+			c.tabID = tab.TabProperties.TabId
 		}
 	}
 
 	return c
 }
 
-// SetComments sets the comments to be appended to the markdown output.
+// setContent uses the body and related objects of a document tab.
+func (c *Converter) setContent(tab *docs.DocumentTab) {
+	c.body = tab.Body
+	c.lists = tab.Lists
+	c.inlineObjects = tab.InlineObjects
+	c.positionedObjects = tab.PositionedObjects
+	c.footnotes = tab.Footnotes
+	c.headers = tab.Headers
+	c.footers = tab.Footers
+	c.documentStyle = tab.DocumentStyle
+}
+
+// SetOptions sets the options that control conversion.
+func (c *Converter) SetOptions(opts Options) {
+	c.opts = opts
+}
+
+// SetComments sets the comments to be appended to the output.
 func (c *Converter) SetComments(comments []gdocs.Comment) {
 	c.comments = comments
 }
 
-// Convert processes the entire document and returns markdown.
-func (c *Converter) Convert() (string, error) {
-	var builder strings.Builder
+// Document builds the document tree. Images are resolved first, so
+// download errors are reported before anything is converted.
+func (c *Converter) Document() (*document.Document, error) {
+	// Numbering starts over on every conversion
+	c.listLevels = nil
+	c.footnoteIDs, c.footnoteNumbers = nil, nil
+	c.headings = nil
+
+	doc := &document.Document{Metadata: c.metadata(), TabID: c.tabID}
+	if c.doc != nil {
+		doc.DocumentID = c.doc.DocumentId
+	}
 
-	// Generate frontmatter
-	frontmatter, err := c.generateFrontmatter()
-	if err != nil {
-		return "", fmt.Errorf("failed to generate frontmatter: %w", err)
+	if err := c.prepareImages(); err != nil {
+		return nil, fmt.Errorf("failed to export images: %w", err)
+	}
+
+	// Render headers above and footers below the body if requested
+	if c.opts.HeadersFooters == HeaderFooterSections {
+		doc.Headers = c.buildHeaderFooterSections("header")
 	}
-	builder.WriteString(frontmatter)
-	builder.WriteString("\n")
 
-	// Convert body content
 	if c.body != nil && c.body.Content != nil {
-		body := c.convertBody()
-		builder.WriteString(body)
+		doc.Blocks = c.buildContent(c.body.Content)
 	}
 
-	// Append comments if present
-	if len(c.comments) > 0 {
-		builder.WriteString("\n")
-		builder.WriteString(ConvertComments(c.comments))
+	if c.opts.HeadersFooters == HeaderFooterSections {
+		doc.Footers = c.buildHeaderFooterSections("footer")
 	}
 
-	return builder.String(), nil
+	// Footnotes are numbered while the body is built
+	doc.Footnotes = c.buildFootnotes()
+	doc.Comments = buildComments(c.comments)
+
+	return doc, nil
 }
 
-// generateFrontmatter creates frontmatter including tab info if present.
-func (c *Converter) generateFrontmatter() (string, error) {
-	// Use the existing GenerateFrontmatter for the base, but we'll
-	// add tab info if we have it
-	frontmatter, err := GenerateFrontmatter(c.doc)
+// Render builds the document tree and renders it with r.
+func (c *Converter) Render(r document.Renderer) (string, error) {
+	doc, err := c.Document()
 	if err != nil {
 		return "", err
 	}
+	return r.Render(doc)
+}
+
+// Convert processes the entire document and returns markdown.
+func (c *Converter) Convert() (string, error) {
+	return c.Render(NewRenderer(c.opts))
+}
+
+// renderer returns a markdown renderer with the converter's options.
+func (c *Converter) renderer() *Renderer {
+	return NewRenderer(c.opts)
+}
+
+// metadata collects the document's metadata, including tab info if
+// present.
+func (c *Converter) metadata() document.Metadata {
+	meta := document.Metadata{Title: c.title}
 
 	// If we have a tab name that differs from the doc title, include it
 	if c.tabName != "" && c.tabName != c.title {
-		// Insert tab info before the closing ---
-		frontmatter = strings.TrimSuffix(frontmatter, "---\n")
-		frontmatter += fmt.Sprintf("tab: %s\n---\n", c.tabName)
+		meta.Tab = c.tabName
+	}
+
+	if c.body != nil {
+		meta.Mentions = collectMentions(c.body.Content)
+	}
+
+	if c.opts.HeadersFooters == HeaderFooterFrontmatter {
+		c.addHeaderFooterFields(&meta)
 	}
 
-	return frontmatter, nil
+	return meta
 }
 
-// convertBody converts the document body to markdown.
-func (c *Converter) convertBody() string {
-	var builder strings.Builder
+// buildContent builds the blocks for a sequence of structural elements,
+// such as the body, a table cell or a header.
+func (c *Converter) buildContent(content []*docs.StructuralElement) []document.Block {
+	var blocks []document.Block
+	var list *listBuilder
+
+	for i := 0; i < len(content); i++ {
+		element := content[i]
+		paragraph := element.Paragraph
+
+		// Consecutive list items form a tree of lists
+		if paragraph != nil && paragraph.Bullet != nil {
+			if list != nil && list.listID != paragraph.Bullet.ListId {
+				list = nil
+			}
+			if list == nil {
+				list = &listBuilder{listID: paragraph.Bullet.ListId}
+				blocks = append(blocks, document.Block{List: list.root()})
+			}
+			c.addListItem(list, paragraph, paragraph.ParagraphStyle)
+			continue
+		}
+
+		// Indented paragraphs under a list item continue the item
+		if list != nil && paragraph != nil {
+			if level, ok := list.continuedLevel(paragraph); ok {
+				list.appendBlocks(level, c.buildParagraphBlocks(paragraph)...)
+				continue
+			}
+		}
+		list = nil
+
+		// Render runs of monospace paragraphs as fenced code blocks
+		if n := codeBlockLength(content[i:]); n > 0 {
+			blocks = append(blocks, c.buildCodeBlock(content[i:i+n]))
+			i += n - 1
+			continue
+		}
+
+		// Render runs of quoted paragraphs as one blockquote
+		if n := c.blockquoteLength(content[i:]); n > 0 {
+			blocks = append(blocks, c.buildBlockquote(content[i:i+n])...)
+			i += n - 1
+			continue
+		}
 
-	for _, element := range c.body.Content {
 		// Convert based on element type
-		if element.Paragraph != nil {
-			markdown := ConvertParagraph(element.Paragraph, element.Paragraph.ParagraphStyle)
-			builder.WriteString(markdown)
+		if paragraph != nil {
+			blocks = append(blocks, c.buildParagraphBlocks(paragraph)...)
 		} else if element.Table != nil {
-			markdown := ConvertTable(element.Table)
-			builder.WriteString(markdown)
+			if table := c.buildTable(element.Table); table != nil {
+				blocks = append(blocks, document.Block{Table: table})
+			}
+		} else if element.TableOfContents != nil {
+			if toc := c.buildTableOfContents(); toc != nil {
+				blocks = append(blocks, document.Block{TableOfContents: toc})
+			}
+		} else if element.SectionBreak != nil {
+			if b := c.buildSectionBreak(element.SectionBreak); b != nil {
+				blocks = append(blocks, document.Block{Break: b})
+			}
 		}
-		// Other structural elements can be added here (e.g., SectionBreak, TableOfContents)
 	}
 
-	return builder.String()
+	return blocks
 }
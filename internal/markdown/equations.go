@@ -0,0 +1,121 @@
+package markdown
+
+import (
+	"strings"
+
+	"github.com/famasya/gdocs-cli/internal/document"
+	"google.golang.org/api/docs/v1"
+)
+
+// equationPlaceholder marks an equation whose content could not be
+// recovered, so readers know a formula is missing.
+const equationPlaceholder = "_[equation]_"
+
+// latexSymbols maps the Unicode symbols the Docs equation editor inserts
+// to their LaTeX commands.
+var latexSymbols = map[rune]string{
+	'α': `\alpha`, 'β': `\beta`, 'γ': `\gamma`, 'δ': `\delta`, 'ε': `\epsilon`,
+	'ζ': `\zeta`, 'η': `\eta`, 'θ': `\theta`, 'ι': `\iota`, 'κ': `\kappa`,
+	'λ': `\lambda`, 'μ': `\mu`, 'ν': `\nu`, 'ξ': `\xi`, 'π': `\pi`,
+	'ρ': `\rho`, 'σ': `\sigma`, 'τ': `\tau`, 'υ': `\upsilon`, 'φ': `\phi`,
+	'χ': `\chi`, 'ψ': `\psi`, 'ω': `\omega`,
+	'Γ': `\Gamma`, 'Δ': `\Delta`, 'Θ': `\Theta`, 'Λ': `\Lambda`, 'Ξ': `\Xi`,
+	'Π': `\Pi`, 'Σ': `\Sigma`, 'Φ': `\Phi`, 'Ψ': `\Psi`, 'Ω': `\Omega`,
+	'≤': `\leq`, '≥': `\geq`, '≠': `\neq`, '≈': `\approx`, '≡': `\equiv`,
+	'∼': `\sim`, '∝': `\propto`, '±': `\pm`, '∓': `\mp`, '×': `\times`,
+	'÷': `\div`, '·': `\cdot`, '∘': `\circ`, '∞': `\infty`, '∂': `\partial`,
+	'∇': `\nabla`, '∑': `\sum`, '∏': `\prod`, '∫': `\int`, '∮': `\oint`,
+	'√': `\sqrt`, '∈': `\in`, '∉': `\notin`, '⊂': `\subset`, '⊆': `\subseteq`,
+	'⊃': `\supset`, '⊇': `\supseteq`, '∪': `\cup`, '∩': `\cap`, '∅': `\emptyset`,
+	'∀': `\forall`, '∃': `\exists`, '¬': `\neg`, '∧': `\wedge`, '∨': `\vee`,
+	'→': `\rightarrow`, '←': `\leftarrow`, '↔': `\leftrightarrow`,
+	'⇒': `\Rightarrow`, '⇐': `\Leftarrow`, '⇔': `\Leftrightarrow`,
+	'…': `\ldots`, '⋯': `\cdots`,
+}
+
+// buildEquation builds the equation at elements[i]. The API does not
+// describe the equation itself, so the formula is rebuilt from the text
+// runs that fall inside the equation's index range. It returns the math
+// and the number of following elements it consumed.
+func buildEquation(elements []*docs.ParagraphElement, i int) (document.Math, int) {
+	eq := elements[i]
+
+	var latex strings.Builder
+	n := 0
+	for _, element := range elements[i+1:] {
+		if element.TextRun == nil || element.StartIndex < eq.StartIndex || element.StartIndex >= eq.EndIndex {
+			break
+		}
+		latex.WriteString(equationRun(element.TextRun))
+		n++
+	}
+
+	math := document.Math{TeX: strings.TrimSpace(latex.String())}
+	math.Display = math.TeX != "" && isDisplayEquation(elements, i, n)
+	return math, n
+}
+
+// renderMath renders an equation as LaTeX math.
+func renderMath(math *document.Math) string {
+	if math.TeX == "" {
+		return equationPlaceholder
+	}
+	if math.Display {
+		return "$$" + math.TeX + "$$"
+	}
+	return "$" + math.TeX + "$"
+}
+
+// equationRun converts one text run of an equation to LaTeX, turning
+// superscript and subscript runs into ^{} and _{} groups.
+func equationRun(run *docs.TextRun) string {
+	text := toLatex(strings.TrimRight(run.Content, "\n"))
+	if text == "" || run.TextStyle == nil {
+		return text
+	}
+	switch run.TextStyle.BaselineOffset {
+	case "SUPERSCRIPT":
+		return "^{" + text + "}"
+	case "SUBSCRIPT":
+		return "_{" + text + "}"
+	}
+	return text
+}
+
+// toLatex replaces math symbols with LaTeX commands, separating a command
+// from a letter that follows it.
+func toLatex(text string) string {
+	var builder strings.Builder
+	runes := []rune(text)
+	for i, r := range runes {
+		cmd, ok := latexSymbols[r]
+		if !ok {
+			builder.WriteRune(r)
+			continue
+		}
+		builder.WriteString(cmd)
+		if i+1 < len(runes) && isASCIILetter(runes[i+1]) {
+			builder.WriteByte(' ')
+		}
+	}
+	return builder.String()
+}
+
+// isASCIILetter reports whether r would continue a LaTeX command name.
+func isASCIILetter(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+}
+
+// isDisplayEquation reports whether the equation at elements[i], with the
+// n runs it consumed, is the only content of its paragraph.
+func isDisplayEquation(elements []*docs.ParagraphElement, i, n int) bool {
+	for j, element := range elements {
+		if j >= i && j <= i+n {
+			continue
+		}
+		if element.TextRun == nil || strings.TrimSpace(element.TextRun.Content) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,56 @@
+package markdown
+
+import (
+	"testing"
+
+	"google.golang.org/api/docs/v1"
+)
+
+func TestConvertEquations(t *testing.T) {
+	tests := []struct {
+		name     string
+		elements []*docs.ParagraphElement
+		want     string
+	}{
+		{
+			name: "inline equation",
+			elements: []*docs.ParagraphElement{
+				{StartIndex: 1, EndIndex: 7, TextRun: &docs.TextRun{Content: "Energy "}},
+				{StartIndex: 7, EndIndex: 12, Equation: &docs.Equation{}},
+				{StartIndex: 7, EndIndex: 11, TextRun: &docs.TextRun{Content: "E=mc"}},
+				{StartIndex: 11, EndIndex: 12, TextRun: &docs.TextRun{Content: "2", TextStyle: &docs.TextStyle{BaselineOffset: "SUPERSCRIPT"}}},
+				{StartIndex: 12, EndIndex: 21, TextRun: &docs.TextRun{Content: " holds.\n"}},
+			},
+			want: "Energy $E=mc^{2}$ holds.\n\n",
+		},
+		{
+			name: "display equation with symbols",
+			elements: []*docs.ParagraphElement{
+				{StartIndex: 1, EndIndex: 8, Equation: &docs.Equation{}},
+				{StartIndex: 1, EndIndex: 2, TextRun: &docs.TextRun{Content: "∑"}},
+				{StartIndex: 2, EndIndex: 3, TextRun: &docs.TextRun{Content: "i", TextStyle: &docs.TextStyle{BaselineOffset: "SUBSCRIPT"}}},
+				{StartIndex: 3, EndIndex: 8, TextRun: &docs.TextRun{Content: "αx≤π"}},
+				{StartIndex: 8, EndIndex: 9, TextRun: &docs.TextRun{Content: "\n"}},
+			},
+			want: "$$\\sum_{i}\\alpha x\\leq\\pi$$\n\n",
+		},
+		{
+			name: "equation without content",
+			elements: []*docs.ParagraphElement{
+				{StartIndex: 1, EndIndex: 5, TextRun: &docs.TextRun{Content: "See "}},
+				{StartIndex: 5, EndIndex: 6, Equation: &docs.Equation{}},
+				{StartIndex: 6, EndIndex: 7, TextRun: &docs.TextRun{Content: "\n"}},
+			},
+			want: "See _[equation]_\n\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ConvertParagraph(&docs.Paragraph{Elements: tt.elements}, nil)
+			if got != tt.want {
+				t.Errorf("ConvertParagraph() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
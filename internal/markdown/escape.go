@@ -0,0 +1,74 @@
+package markdown
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// escapeText escapes characters in literal document text that markdown
+// would otherwise treat as inline syntax. Underscores inside words are left
+// alone, since they never start emphasis.
+func escapeText(text string) string {
+	runes := []rune(text)
+	var builder strings.Builder
+	builder.Grow(len(text))
+
+	for i, r := range runes {
+		switch r {
+		case '\\', '*', '`', '[', ']', '<', '~':
+			builder.WriteRune('\\')
+		case '_':
+			if !(i > 0 && isWordRune(runes[i-1]) && i+1 < len(runes) && isWordRune(runes[i+1])) {
+				builder.WriteRune('\\')
+			}
+		case '&':
+			// Only an entity reference like &amp; or &#42; needs escaping
+			if entityPattern.MatchString(string(runes[i:])) {
+				builder.WriteRune('\\')
+			}
+		}
+		builder.WriteRune(r)
+	}
+
+	return builder.String()
+}
+
+// entityPattern matches an HTML entity reference at the start of a string.
+var entityPattern = regexp.MustCompile(`^&(#[0-9]{1,7}|#[xX][0-9a-fA-F]{1,6}|[a-zA-Z][a-zA-Z0-9]{1,31});`)
+
+// isWordRune reports whether r is a letter or digit.
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// Patterns for text at the start of a line that markdown would read as
+// block syntax.
+var (
+	atxHeadingPattern    = regexp.MustCompile(`^#{1,6}(\s|$)`)
+	bulletMarkerPattern  = regexp.MustCompile(`^[-+](\s|$)`)
+	orderedMarkerPattern = regexp.MustCompile(`^(\d{1,9})([.)])(\s|$)`)
+	thematicBreakPattern = regexp.MustCompile(`^(-[ \t]*){3,}$`)
+)
+
+// escapeLineStart escapes text at the start of a line that would otherwise
+// be read as a heading, blockquote, list marker or thematic break. Leading
+// spaces and tabs are dropped: markdown ignores up to three of them, and
+// more would start an indented code block.
+func escapeLineStart(line string) string {
+	line = strings.TrimLeft(line, " \t")
+	switch {
+	case atxHeadingPattern.MatchString(line),
+		strings.HasPrefix(line, ">"),
+		bulletMarkerPattern.MatchString(line),
+		thematicBreakPattern.MatchString(line):
+		return "\\" + line
+	}
+
+	if m := orderedMarkerPattern.FindStringSubmatchIndex(line); m != nil {
+		// Escape the delimiter, e.g. "1." becomes "1\."
+		return line[:m[3]] + "\\" + line[m[3]:]
+	}
+
+	return line
+}
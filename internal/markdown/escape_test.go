@@ -0,0 +1,102 @@
+package markdown
+
+import (
+	"testing"
+
+	"google.golang.org/api/docs/v1"
+)
+
+func TestEscapeText(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"plain", "Hello World", "Hello World"},
+		{"asterisks", "2 * 3 * 4", "2 \\* 3 \\* 4"},
+		{"emphasis underscores", "_not italic_", "\\_not italic\\_"},
+		{"intraword underscore", "snake_case_name", "snake_case_name"},
+		{"brackets", "[draft]", "\\[draft\\]"},
+		{"html", "<div>", "\\<div>"},
+		{"backslash", `C:\temp`, `C:\\temp`},
+		{"backtick", "a`b", "a\\`b"},
+		{"tilde", "~~gone~~", "\\~\\~gone\\~\\~"},
+		{"entity", "&amp; and & alone", "\\&amp; and & alone"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := escapeText(tt.text); got != tt.want {
+				t.Errorf("escapeText(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEscapeLineStart(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want string
+	}{
+		{"heading", "# not a heading", "\\# not a heading"},
+		{"hashtag", "#hashtag", "#hashtag"},
+		{"blockquote", "> quoted", "\\> quoted"},
+		{"dash bullet", "- item", "\\- item"},
+		{"plus bullet", "+ item", "\\+ item"},
+		{"hyphenated word", "-flag", "-flag"},
+		{"ordered", "1. First", "1\\. First"},
+		{"ordered paren", "12) Twelve", "12\\) Twelve"},
+		{"version number", "1.5 release", "1.5 release"},
+		{"thematic break", "---", "\\---"},
+		{"plain", "Text", "Text"},
+		{"tab", "\tIndented", "Indented"},
+		{"four spaces", "    Indented", "Indented"},
+		{"indented bullet", "  - item", "\\- item"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := escapeLineStart(tt.line); got != tt.want {
+				t.Errorf("escapeLineStart(%q) = %q, want %q", tt.line, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvertParagraphEscaping(t *testing.T) {
+	paragraph := &docs.Paragraph{
+		Elements: []*docs.ParagraphElement{
+			{TextRun: &docs.TextRun{Content: "1. Use "}},
+			{TextRun: &docs.TextRun{Content: "*ptr", TextStyle: mono("Courier New")}},
+			{TextRun: &docs.TextRun{Content: " and see "}},
+			{TextRun: &docs.TextRun{Content: "[spec]", TextStyle: &docs.TextStyle{Link: &docs.Link{Url: "https://example.com/a_b*c"}}}},
+			{TextRun: &docs.TextRun{Content: "\n"}},
+		},
+	}
+
+	tests := []struct {
+		name string
+		opts Options
+		want string
+	}{
+		{
+			name: "escaped",
+			want: "1\\. Use `*ptr` and see [\\[spec\\]](https://example.com/a_b*c)\n\n",
+		},
+		{
+			name: "raw text",
+			opts: Options{RawText: true},
+			want: "1. Use `*ptr` and see [[spec]](https://example.com/a_b*c)\n\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Converter{opts: tt.opts}
+			if got := c.convertParagraph(paragraph, &docs.ParagraphStyle{}); got != tt.want {
+				t.Errorf("convertParagraph() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package markdown
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/famasya/gdocs-cli/internal/document"
+)
+
+// link renders a link. Obsidian links to headings and to other exported
+// documents become wikilinks; other links are markdown links.
+func (r *Renderer) link(text, url string) string {
+	if r.opts.Flavor == FlavorObsidian {
+		if target, ok := r.wikilinkTarget(url); ok {
+			return "[[" + target + "|" + strings.TrimRight(text, "\n") + "]]"
+		}
+	}
+	return formatLink(text, url)
+}
+
+// wikilinkTarget returns the wikilink target of a link to a heading in
+// this document or to another document's markdown file. Obsidian links
+// to headings by their text and cannot address tabs or headings of other
+// documents, so those parts of a document link are dropped.
+func (r *Renderer) wikilinkTarget(url string) (string, bool) {
+	if anchor, ok := strings.CutPrefix(url, "#"); ok {
+		text, ok := r.headings[anchor]
+		return "#" + text, ok
+	}
+	if strings.Contains(url, ":") {
+		return "", false
+	}
+	file, _, _ := strings.Cut(url, "#")
+	file, _, _ = strings.Cut(file, "?")
+	if path.Ext(file) != ".md" {
+		return "", false
+	}
+	return strings.TrimSuffix(file, ".md"), true
+}
+
+// headingTexts maps the anchors of a document's headings to their text
+// as written, which Obsidian links to headings by. Characters Obsidian
+// does not allow in links are dropped.
+func headingTexts(blocks []document.Block) map[string]string {
+	texts := make(map[string]string)
+	for _, block := range blocks {
+		heading := block.Heading
+		if heading == nil || heading.Anchor == "" {
+			continue
+		}
+		text := strings.Map(func(r rune) rune {
+			if strings.ContainsRune("#|^:[]", r) {
+				return ' '
+			}
+			return r
+		}, headingText(heading))
+		texts[heading.Anchor] = strings.Join(strings.Fields(text), " ")
+	}
+	return texts
+}
+
+// checkbox returns the marker of a checklist item. CommonMark has no task
+// lists, so it gets a disabled HTML checkbox.
+func (r *Renderer) checkbox(checked bool) string {
+	if r.opts.Flavor == FlavorCommonMark {
+		if checked {
+			return `<input type="checkbox" disabled checked> `
+		}
+		return `<input type="checkbox" disabled> `
+	}
+	if checked {
+		return "[x] "
+	}
+	return "[ ] "
+}
+
+// footnoteRef renders a reference to a footnote. CommonMark has no
+// footnotes, so it gets a superscript link to the definition.
+func (r *Renderer) footnoteRef(number int) string {
+	if r.opts.Flavor == FlavorCommonMark {
+		return fmt.Sprintf(`<sup id="fnref-%d"><a href="#fn-%d">%d</a></sup>`, number, number, number)
+	}
+	return fmt.Sprintf("[^%d]", number)
+}
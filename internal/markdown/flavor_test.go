@@ -0,0 +1,107 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/famasya/gdocs-cli/internal/document"
+)
+
+func TestFlavors(t *testing.T) {
+	checked := true
+	text := func(value string, style document.TextStyle) document.Inline {
+		return document.Inline{Text: &document.Text{Value: value, TextStyle: style}}
+	}
+	paragraph := func(inlines ...document.Inline) document.Block {
+		return document.Block{Paragraph: &document.Paragraph{Inlines: inlines}}
+	}
+	doc := &document.Document{
+		Blocks: []document.Block{
+			{Heading: &document.Heading{Level: 2, Number: "1.", Anchor: "next-steps", Inlines: []document.Inline{text("Next: steps", document.TextStyle{})}}},
+			paragraph(
+				text("old", document.TextStyle{Strikethrough: true}),
+				text(" ", document.TextStyle{}),
+				text("key", document.TextStyle{Background: document.HighlightColor}),
+				text(" see ", document.TextStyle{}),
+				text("design", document.TextStyle{Link: "../specs/design.md?tab=t.1#heading=h.abc"}),
+				text(", ", document.TextStyle{}),
+				text("above", document.TextStyle{Link: "#next-steps"}),
+				document.Inline{FootnoteRef: &document.FootnoteRef{Number: 1}},
+			),
+			{List: &document.List{Items: []document.ListItem{
+				{Checked: &checked, Blocks: []document.Block{paragraph(text("Done", document.TextStyle{}))}},
+			}}},
+			{Table: &document.Table{Rows: []document.TableRow{
+				{Cells: []document.TableCell{{ColSpan: 2, Blocks: []document.Block{paragraph(text("Team", document.TextStyle{}))}}}},
+				{Cells: []document.TableCell{
+					{Blocks: []document.Block{paragraph(text("Ada", document.TextStyle{}))}},
+					{Blocks: []document.Block{paragraph(text("Lead", document.TextStyle{})), paragraph(text("Ops", document.TextStyle{}))}},
+				}},
+			}}},
+		},
+		Footnotes: []document.Footnote{{Number: 1, Blocks: []document.Block{paragraph(text("Note", document.TextStyle{}))}}},
+	}
+
+	tests := []struct {
+		name string
+		opts Options
+		want string
+	}{
+		{
+			name: "gfm",
+			opts: Options{},
+			want: "## 1. Next: steps\n\n" +
+				"~~old~~ key see [design](../specs/design.md?tab=t.1#heading=h.abc), [above](#next-steps)[^1]\n\n" +
+				"- [x] Done\n\n" +
+				"<table>\n<tr>\n<th colspan=\"2\">Team</th>\n</tr>\n<tr>\n<td>Ada</td>\n<td>\n\nLead\n\nOps\n\n</td>\n</tr>\n</table>\n\n" +
+				"\n[^1]: Note\n",
+		},
+		{
+			name: "commonmark",
+			opts: Options{Flavor: FlavorCommonMark},
+			want: "## <a id=\"next-steps\"></a>1. Next: steps\n\n" +
+				"<del>old</del> key see [design](../specs/design.md?tab=t.1#heading=h.abc), [above](#next-steps)<sup id=\"fnref-1\"><a href=\"#fn-1\">1</a></sup>\n\n" +
+				"- <input type=\"checkbox\" disabled checked> Done\n\n" +
+				"<table>\n<tr>\n<th colspan=\"2\">Team</th>\n</tr>\n<tr>\n<td>Ada</td>\n<td>\n\nLead\n\nOps\n\n</td>\n</tr>\n</table>\n\n" +
+				"\n<sup id=\"fn-1\">1</sup> Note\n",
+		},
+		{
+			name: "obsidian",
+			opts: Options{Flavor: FlavorObsidian},
+			want: "## 1. Next: steps\n\n" +
+				"~~old~~ ==key== see [[../specs/design|design]], [[#1. Next steps|above]][^1]\n\n" +
+				"- [x] Done\n\n" +
+				"<table>\n<tr>\n<th colspan=\"2\">Team</th>\n</tr>\n<tr>\n<td>Ada</td>\n<td>\n\nLead\n\nOps\n\n</td>\n</tr>\n</table>\n\n" +
+				"\n[^1]: Note\n",
+		},
+		{
+			name: "pandoc",
+			opts: Options{Flavor: FlavorPandoc},
+			want: "## 1. Next: steps {#next-steps}\n\n" +
+				"~~old~~ key see [design](../specs/design.md?tab=t.1#heading=h.abc), [above](#next-steps)[^1]\n\n" +
+				"- [x] Done\n\n" +
+				"+-----+------+\n" +
+				"| Team       |\n" +
+				"+=====+======+\n" +
+				"| Ada | Lead |\n" +
+				"|     |      |\n" +
+				"|     | Ops  |\n" +
+				"+-----+------+\n\n" +
+				"\n[^1]: Note\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewRenderer(tt.opts).Render(doc)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			// Skip the empty frontmatter
+			_, got, _ = strings.Cut(got, "---\n\n")
+			if got != tt.want {
+				t.Errorf("Render() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
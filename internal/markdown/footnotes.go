@@ -0,0 +1,80 @@
+package markdown
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/famasya/gdocs-cli/internal/document"
+	"google.golang.org/api/docs/v1"
+)
+
+// footnoteRef builds a reference to a footnote. Footnotes are numbered in
+// order of first reference.
+func (c *Converter) footnoteRef(ref *docs.FootnoteReference) *document.FootnoteRef {
+	if c.footnoteNumbers == nil {
+		c.footnoteNumbers = make(map[string]int)
+	}
+
+	number, ok := c.footnoteNumbers[ref.FootnoteId]
+	if !ok {
+		c.footnoteIDs = append(c.footnoteIDs, ref.FootnoteId)
+		number = len(c.footnoteIDs)
+		c.footnoteNumbers[ref.FootnoteId] = number
+	}
+
+	return &document.FootnoteRef{Number: number}
+}
+
+// buildFootnotes builds the content of all referenced footnotes. Footnotes
+// referenced from other footnotes are included as they are numbered.
+func (c *Converter) buildFootnotes() []document.Footnote {
+	var footnotes []document.Footnote
+
+	for i := 0; i < len(c.footnoteIDs); i++ {
+		footnote, ok := c.footnotes[c.footnoteIDs[i]]
+		if !ok {
+			continue
+		}
+		footnotes = append(footnotes, document.Footnote{
+			Number: i + 1,
+			Blocks: c.buildContent(footnote.Content),
+		})
+	}
+
+	return footnotes
+}
+
+// renderFootnotes renders the definitions of footnotes.
+func (r *Renderer) renderFootnotes(footnotes []document.Footnote) string {
+	var builder strings.Builder
+
+	for _, footnote := range footnotes {
+		var paragraphs []string
+		for _, block := range footnote.Blocks {
+			if text := strings.TrimSpace(r.renderBlock(block)); text != "" {
+				paragraphs = append(paragraphs, text)
+			}
+		}
+		if len(paragraphs) == 0 {
+			continue
+		}
+
+		// CommonMark has no footnote definitions; write each as paragraphs
+		// led by its number, which references link to
+		if r.opts.Flavor == FlavorCommonMark {
+			if builder.Len() > 0 {
+				builder.WriteString("\n")
+			}
+			fmt.Fprintf(&builder, "<sup id=\"fn-%d\">%d</sup> %s\n", footnote.Number, footnote.Number, strings.Join(paragraphs, "\n\n"))
+			continue
+		}
+
+		// Continuation paragraphs are indented to belong to the footnote
+		body := strings.Join(paragraphs, "\n\n")
+		body = strings.ReplaceAll(body, "\n", "\n    ")
+		body = strings.ReplaceAll(body, "\n    \n", "\n\n")
+		builder.WriteString(fmt.Sprintf("[^%d]: %s\n", footnote.Number, body))
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,96 @@
+package markdown
+
+import (
+	"testing"
+
+	"google.golang.org/api/docs/v1"
+)
+
+func footnoteParagraph(text string) *docs.StructuralElement {
+	return &docs.StructuralElement{
+		Paragraph: &docs.Paragraph{
+			Elements: []*docs.ParagraphElement{
+				{TextRun: &docs.TextRun{Content: text}},
+			},
+		},
+	}
+}
+
+func TestConvertFootnotes(t *testing.T) {
+	ref := func(id string) *docs.ParagraphElement {
+		return &docs.ParagraphElement{FootnoteReference: &docs.FootnoteReference{FootnoteId: id}}
+	}
+
+	doc := &docs.Document{
+		Title: "Footnotes",
+		Tabs: []*docs.Tab{
+			{
+				DocumentTab: &docs.DocumentTab{
+					Body: &docs.Body{
+						Content: []*docs.StructuralElement{
+							{
+								Paragraph: &docs.Paragraph{
+									Elements: []*docs.ParagraphElement{
+										{TextRun: &docs.TextRun{Content: "Claim"}},
+										ref("kix.b"),
+										{TextRun: &docs.TextRun{Content: " and another"}},
+										ref("kix.a"),
+										{TextRun: &docs.TextRun{Content: " and again"}},
+										ref("kix.b"),
+										{TextRun: &docs.TextRun{Content: ".\n"}},
+									},
+								},
+							},
+						},
+					},
+					Footnotes: map[string]docs.Footnote{
+						"kix.a": {
+							FootnoteId: "kix.a",
+							Content: []*docs.StructuralElement{
+								footnoteParagraph(" First paragraph.\n"),
+								footnoteParagraph("Second paragraph.\n"),
+							},
+						},
+						"kix.b": {
+							FootnoteId: "kix.b",
+							Content: []*docs.StructuralElement{
+								{
+									Paragraph: &docs.Paragraph{
+										Elements: []*docs.ParagraphElement{
+											{TextRun: &docs.TextRun{Content: " See "}},
+											{TextRun: &docs.TextRun{Content: "the spec", TextStyle: &docs.TextStyle{Italic: true}}},
+											{TextRun: &docs.TextRun{Content: ".\n"}},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	got, err := NewConverter(doc).Convert()
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+
+	want := "---\ntitle: Footnotes\n---\n\n" +
+		"Claim[^1] and another[^2] and again[^1].\n\n" +
+		"\n[^1]: See *the spec*.\n" +
+		"[^2]: First paragraph.\n\n    Second paragraph.\n"
+	if got != want {
+		t.Errorf("Convert() = %q, want %q", got, want)
+	}
+}
+
+func TestConvertFootnoteReferenceWithoutDocument(t *testing.T) {
+	got := convertElements(t, &Converter{}, []*docs.ParagraphElement{
+		{TextRun: &docs.TextRun{Content: "Text"}},
+		{FootnoteReference: &docs.FootnoteReference{FootnoteId: "kix.x", FootnoteNumber: "7"}},
+	})
+	if want := "Text[^1]"; got != want {
+		t.Errorf("convertElements() = %q, want %q", got, want)
+	}
+}
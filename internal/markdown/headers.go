@@ -0,0 +1,132 @@
+package markdown
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/famasya/gdocs-cli/internal/document"
+	"google.golang.org/api/docs/v1"
+)
+
+// headerFooterVariant is one of the headers or footers a document may use.
+type headerFooterVariant struct {
+	label string // label used in section delimiters
+	id    string // header or footer ID
+}
+
+// headerFooterVariants returns the default, first page and even page
+// variants of the document's headers or footers, skipping missing ones.
+// kind is either "header" or "footer".
+func (c *Converter) headerFooterVariants(kind string) []headerFooterVariant {
+	style := c.documentStyle
+	if style == nil {
+		return nil
+	}
+
+	var variants []headerFooterVariant
+	if kind == "header" {
+		variants = []headerFooterVariant{
+			{label: "header", id: style.DefaultHeaderId},
+			{label: "header first page", id: style.FirstPageHeaderId},
+			{label: "header even page", id: style.EvenPageHeaderId},
+		}
+	} else {
+		variants = []headerFooterVariant{
+			{label: "footer", id: style.DefaultFooterId},
+			{label: "footer first page", id: style.FirstPageFooterId},
+			{label: "footer even page", id: style.EvenPageFooterId},
+		}
+	}
+
+	present := variants[:0]
+	for _, v := range variants {
+		if v.id != "" && c.headerFooterContent(kind, v.id) != nil {
+			present = append(present, v)
+		}
+	}
+	return present
+}
+
+// headerFooterContent returns the content of a header or footer by ID.
+func (c *Converter) headerFooterContent(kind, id string) []*docs.StructuralElement {
+	if kind == "header" {
+		if header, ok := c.headers[id]; ok {
+			return header.Content
+		}
+		return nil
+	}
+	if footer, ok := c.footers[id]; ok {
+		return footer.Content
+	}
+	return nil
+}
+
+// buildHeaderFooterSections builds the document's headers or footers as
+// sections.
+func (c *Converter) buildHeaderFooterSections(kind string) []document.Section {
+	var sections []document.Section
+	for _, v := range c.headerFooterVariants(kind) {
+		sections = append(sections, document.Section{
+			Kind:   kind,
+			Label:  v.label,
+			Blocks: c.buildContent(c.headerFooterContent(kind, v.id)),
+		})
+	}
+	return sections
+}
+
+// renderSections renders headers or footers as markdown sections
+// delimited by HTML comments.
+func (r *Renderer) renderSections(sections []document.Section) string {
+	var builder strings.Builder
+
+	for _, section := range sections {
+		text := strings.TrimSpace(r.renderBlocks(section.Blocks))
+		if text == "" {
+			continue
+		}
+		builder.WriteString(fmt.Sprintf("<!-- %s -->\n%s\n<!-- /%s -->\n\n", section.Label, text, section.Kind))
+	}
+
+	return builder.String()
+}
+
+// addHeaderFooterFields sets the header and footer fields of the frontmatter
+// to the plain text of the document's headers and footers.
+func (c *Converter) addHeaderFooterFields(fm *document.Metadata) {
+	fields := map[string]*string{
+		"header":            &fm.Header,
+		"header first page": &fm.HeaderFirstPage,
+		"header even page":  &fm.HeaderEvenPage,
+		"footer":            &fm.Footer,
+		"footer first page": &fm.FooterFirstPage,
+		"footer even page":  &fm.FooterEvenPage,
+	}
+
+	for _, kind := range []string{"header", "footer"} {
+		for _, v := range c.headerFooterVariants(kind) {
+			*fields[v.label] = plainText(c.headerFooterContent(kind, v.id))
+		}
+	}
+}
+
+// plainText extracts the text of structural elements, one line per
+// paragraph, without formatting.
+func plainText(content []*docs.StructuralElement) string {
+	var lines []string
+	for _, element := range content {
+		if element.Paragraph == nil {
+			continue
+		}
+		var line strings.Builder
+		for _, el := range element.Paragraph.Elements {
+			if el.TextRun != nil {
+				line.WriteString(el.TextRun.Content)
+			}
+		}
+		if text := strings.TrimSpace(strings.ReplaceAll(line.String(), "\v", " ")); text != "" {
+			lines = append(lines, text)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,76 @@
+package markdown
+
+import (
+	"testing"
+
+	"google.golang.org/api/docs/v1"
+)
+
+func headerFooterDocument() *docs.Document {
+	text := func(s string) []*docs.StructuralElement {
+		return []*docs.StructuralElement{
+			{Paragraph: &docs.Paragraph{Elements: []*docs.ParagraphElement{{TextRun: &docs.TextRun{Content: s}}}}},
+		}
+	}
+	return &docs.Document{
+		Title: "Spec",
+		Body: &docs.Body{
+			Content: text("Body text.\n"),
+		},
+		DocumentStyle: &docs.DocumentStyle{
+			DefaultHeaderId:   "h.default",
+			FirstPageHeaderId: "h.first",
+			EvenPageHeaderId:  "h.missing",
+			DefaultFooterId:   "f.default",
+		},
+		Headers: map[string]docs.Header{
+			"h.default": {HeaderId: "h.default", Content: text("CONFIDENTIAL\n")},
+			"h.first":   {HeaderId: "h.first", Content: text("Cover\n")},
+		},
+		Footers: map[string]docs.Footer{
+			"f.default": {FooterId: "f.default", Content: text("Version 1.2\n")},
+		},
+	}
+}
+
+func TestConvertHeadersFooters(t *testing.T) {
+	tests := []struct {
+		name string
+		mode HeaderFooterMode
+		want string
+	}{
+		{
+			name: "omitted by default",
+			mode: "",
+			want: "---\ntitle: Spec\n---\n\nBody text.\n\n",
+		},
+		{
+			name: "sections",
+			mode: HeaderFooterSections,
+			want: "---\ntitle: Spec\n---\n\n" +
+				"<!-- header -->\nCONFIDENTIAL\n<!-- /header -->\n\n" +
+				"<!-- header first page -->\nCover\n<!-- /header -->\n\n" +
+				"Body text.\n\n" +
+				"<!-- footer -->\nVersion 1.2\n<!-- /footer -->\n\n",
+		},
+		{
+			name: "frontmatter",
+			mode: HeaderFooterFrontmatter,
+			want: "---\ntitle: Spec\nheader: CONFIDENTIAL\nheader_first_page: Cover\nfooter: Version 1.2\n---\n\nBody text.\n\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewConverter(headerFooterDocument())
+			c.SetOptions(Options{HeadersFooters: tt.mode})
+			got, err := c.Convert()
+			if err != nil {
+				t.Fatalf("Convert() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Convert() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package markdown
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/famasya/gdocs-cli/internal/document"
+	"google.golang.org/api/docs/v1"
+)
+
+// defaultMarkdownLevels maps paragraph styles to markdown heading levels.
+var defaultMarkdownLevels = map[string]int{
+	"TITLE":     1,
+	"SUBTITLE":  2,
+	"HEADING_1": 1,
+	"HEADING_2": 2,
+	"HEADING_3": 3,
+	"HEADING_4": 4,
+	"HEADING_5": 5,
+	"HEADING_6": 6,
+}
+
+// buildHeading builds a title, subtitle or heading paragraph. It returns
+// false for a dropped title.
+func (c *Converter) buildHeading(paragraph *docs.Paragraph, style *docs.ParagraphStyle, inlines []document.Inline) (document.Block, bool) {
+	switch style.NamedStyleType {
+	case "TITLE":
+		if c.opts.DropTitle {
+			return document.Block{}, false
+		}
+	case "SUBTITLE":
+		if c.opts.SubtitleEmphasis {
+			return document.Block{Paragraph: &document.Paragraph{Inlines: emphasized(inlines)}}, true
+		}
+	}
+
+	heading := &document.Heading{
+		Level:   c.markdownLevel(style.NamedStyleType),
+		Style:   style.NamedStyleType,
+		Inlines: inlines,
+	}
+	if h, ok := c.paragraphHeading(paragraph); ok {
+		heading.Number = h.number
+		heading.Anchor = h.anchor
+		heading.ID = h.id
+	}
+	return document.Block{Heading: heading}, true
+}
+
+// emphasized returns a copy of the inlines with their text in italics.
+func emphasized(inlines []document.Inline) []document.Inline {
+	result := make([]document.Inline, len(inlines))
+	for i, inline := range inlines {
+		if inline.Text != nil {
+			text := *inline.Text
+			text.Italic = true
+			inline.Text = &text
+		}
+		result[i] = inline
+	}
+	return result
+}
+
+// renderHeading renders a heading on a single line.
+func (r *Renderer) renderHeading(heading *document.Heading) string {
+	text := r.blockText(heading.Inlines)
+	if text == "" {
+		return "\n"
+	}
+
+	// Headings are a single line
+	text = strings.ReplaceAll(text, "\v", " ")
+
+	if heading.Number != "" {
+		text = heading.Number + " " + text
+	}
+	// Pandoc derives its own IDs and CommonMark none, so headings always
+	// keep their anchor there. CommonMark has no heading attributes and
+	// gets an HTML anchor.
+	switch {
+	case heading.Anchor == "":
+	case r.opts.Flavor == FlavorPandoc:
+		text += " {#" + heading.Anchor + "}"
+	case r.opts.Flavor == FlavorCommonMark:
+		text = `<a id="` + heading.Anchor + `"></a>` + text
+	case r.opts.HeadingAnchors != AnchorsExplicit:
+	default:
+		text += " {#" + heading.Anchor + "}"
+	}
+
+	return strings.Repeat("#", heading.Level) + " " + text + "\n\n"
+}
+
+// headingText returns the plain text of a heading with its number.
+func headingText(heading *document.Heading) string {
+	text := strings.TrimSpace(document.PlainText(heading.Inlines))
+	if heading.Number != "" {
+		text = heading.Number + " " + text
+	}
+	return text
+}
+
+// markdownLevel returns the markdown heading level for a paragraph style,
+// after the custom mapping and heading shift. Levels past 6 are rendered
+// as level 6, the deepest markdown heading.
+func (c *Converter) markdownLevel(namedStyle string) int {
+	level, ok := c.opts.HeadingLevels[namedStyle]
+	if !ok {
+		level, ok = defaultMarkdownLevels[namedStyle]
+	}
+	if !ok {
+		level = 6
+	}
+	return min(max(level+c.opts.HeadingShift, 1), 6)
+}
+
+// ParseHeadingLevels parses a comma-separated list of STYLE=LEVEL pairs,
+// such as TITLE=1,HEADING_1=2, into a heading level mapping.
+func ParseHeadingLevels(spec string) (map[string]int, error) {
+	levels := make(map[string]int)
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(item, "=")
+		name = strings.ToUpper(strings.TrimSpace(name))
+		if !ok {
+			return nil, fmt.Errorf("invalid heading level '%s': expected STYLE=LEVEL", item)
+		}
+		if _, known := defaultMarkdownLevels[name]; !known {
+			return nil, fmt.Errorf("invalid heading style '%s': expected TITLE, SUBTITLE or HEADING_1 to HEADING_6", name)
+		}
+		level, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || level < 1 || level > 6 {
+			return nil, fmt.Errorf("invalid level for %s '%s': expected 1 to 6", name, value)
+		}
+		levels[name] = level
+	}
+	return levels, nil
+}
@@ -0,0 +1,131 @@
+package markdown
+
+import (
+	"reflect"
+	"testing"
+
+	"google.golang.org/api/docs/v1"
+)
+
+func TestConvertHeadingOptions(t *testing.T) {
+	content := func() []*docs.StructuralElement {
+		return []*docs.StructuralElement{
+			headingParagraph("TITLE", "Design"),
+			headingParagraph("SUBTITLE", "Draft"),
+			headingParagraph("HEADING_1", "Overview"),
+			headingParagraph("HEADING_2", "Goals"),
+			headingParagraph("HEADING_2", "Non-goals"),
+			headingParagraph("HEADING_1", "Details"),
+			headingParagraph("HEADING_3", "Storage"),
+		}
+	}
+
+	tests := []struct {
+		name string
+		opts Options
+		want string
+	}{
+		{
+			name: "default",
+			want: "# Design\n\n## Draft\n\n# Overview\n\n## Goals\n\n## Non-goals\n\n# Details\n\n### Storage\n\n",
+		},
+		{
+			name: "shift",
+			opts: Options{HeadingShift: 2},
+			want: "### Design\n\n#### Draft\n\n### Overview\n\n#### Goals\n\n#### Non-goals\n\n### Details\n\n##### Storage\n\n",
+		},
+		{
+			name: "shift stops at level 6",
+			opts: Options{HeadingShift: 4},
+			want: "##### Design\n\n###### Draft\n\n##### Overview\n\n###### Goals\n\n###### Non-goals\n\n##### Details\n\n###### Storage\n\n",
+		},
+		{
+			name: "drop title and emphasize subtitle",
+			opts: Options{DropTitle: true, SubtitleEmphasis: true},
+			want: "*Draft*\n\n# Overview\n\n## Goals\n\n## Non-goals\n\n# Details\n\n### Storage\n\n",
+		},
+		{
+			name: "custom mapping",
+			opts: Options{HeadingLevels: map[string]int{"HEADING_1": 2, "HEADING_2": 3, "HEADING_3": 4}},
+			want: "# Design\n\n## Draft\n\n## Overview\n\n### Goals\n\n### Non-goals\n\n## Details\n\n#### Storage\n\n",
+		},
+		{
+			name: "numbering",
+			opts: Options{NumberHeadings: true, DropTitle: true, SubtitleEmphasis: true},
+			want: "*Draft*\n\n# 1 Overview\n\n## 1.1 Goals\n\n## 1.2 Non-goals\n\n# 2 Details\n\n### 2.1 Storage\n\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewConverter(&docs.Document{Body: &docs.Body{Content: content()}})
+			c.SetOptions(tt.opts)
+			if got := convertBody(t, c); got != tt.want {
+				t.Errorf("convertBody() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNumberedHeadingLinks(t *testing.T) {
+	goals := headingParagraph("HEADING_2", "Goals")
+	goals.Paragraph.ParagraphStyle.HeadingId = "h.goals"
+	doc := &docs.Document{Body: &docs.Body{Content: []*docs.StructuralElement{
+		headingParagraph("HEADING_1", "Overview"),
+		goals,
+		linkedParagraph("see", &docs.Link{HeadingId: "h.goals"}),
+	}}}
+
+	c := NewConverter(doc)
+	c.SetOptions(Options{NumberHeadings: true})
+	want := "# 1 Overview\n\n## 1.1 Goals\n\n[see](#11-goals)\n\n"
+	if got := convertBody(t, c); got != want {
+		t.Errorf("convertBody() = %q, want %q", got, want)
+	}
+}
+
+func TestNumberedHeadingsSkippedLevels(t *testing.T) {
+	deep := headingParagraph("HEADING_3", "Deep")
+	deep.Paragraph.ParagraphStyle.HeadingId = "h.deep"
+	doc := &docs.Document{Body: &docs.Body{Content: []*docs.StructuralElement{
+		headingParagraph("HEADING_2", "Intro"),
+		headingParagraph("HEADING_1", "Overview"),
+		deep,
+		headingParagraph("HEADING_2", "Goals"),
+		headingParagraph("HEADING_4", "Detail"),
+		linkedParagraph("see", &docs.Link{HeadingId: "h.deep"}),
+	}}}
+
+	c := NewConverter(doc)
+	c.SetOptions(Options{NumberHeadings: true})
+	want := "## 1 Intro\n\n# 2 Overview\n\n### 2.1 Deep\n\n## 2.2 Goals\n\n#### 2.2.1 Detail\n\n[see](#21-deep)\n\n"
+	if got := convertBody(t, c); got != want {
+		t.Errorf("convertBody() = %q, want %q", got, want)
+	}
+}
+
+func TestParseHeadingLevels(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    map[string]int
+		wantErr bool
+	}{
+		{spec: "", want: map[string]int{}},
+		{spec: "TITLE=1, heading_1=2", want: map[string]int{"TITLE": 1, "HEADING_1": 2}},
+		{spec: "HEADING_7=2", wantErr: true},
+		{spec: "HEADING_1=7", wantErr: true},
+		{spec: "HEADING_1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			got, err := ParseHeadingLevels(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseHeadingLevels(%q) error = %v, wantErr %v", tt.spec, err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseHeadingLevels(%q) = %v, want %v", tt.spec, got, tt.want)
+			}
+		})
+	}
+}
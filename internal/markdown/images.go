@@ -0,0 +1,196 @@
+package markdown
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/famasya/gdocs-cli/internal/document"
+	"google.golang.org/api/docs/v1"
+)
+
+// prepareImages resolves the link target of every image in the document.
+// Depending on the image mode, images are downloaded to the assets
+// directory or embedded as data URIs.
+func (c *Converter) prepareImages() error {
+	c.images = make(map[string]string)
+
+	objects := make(map[string]*docs.EmbeddedObject)
+	for id, obj := range c.inlineObjects {
+		if obj.InlineObjectProperties != nil {
+			objects[id] = obj.InlineObjectProperties.EmbeddedObject
+		}
+	}
+	for id, obj := range c.positionedObjects {
+		if obj.PositionedObjectProperties != nil {
+			objects[id] = obj.PositionedObjectProperties.EmbeddedObject
+		}
+	}
+
+	// Process objects in a stable order so errors are deterministic
+	ids := make([]string, 0, len(objects))
+	for id := range objects {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		uri := imageURI(objects[id])
+		if uri == "" {
+			continue
+		}
+
+		switch c.opts.Images {
+		case ImagesFiles, ImagesInline:
+			if c.opts.FetchImage == nil {
+				return fmt.Errorf("no image fetcher configured for image mode %q", c.opts.Images)
+			}
+			data, contentType, err := c.opts.FetchImage(uri)
+			if err != nil {
+				return fmt.Errorf("failed to download image %s: %w", id, err)
+			}
+			if contentType == "" {
+				contentType = http.DetectContentType(data)
+			}
+
+			if c.opts.Images == ImagesInline {
+				c.images[id] = dataURI(data, contentType)
+				continue
+			}
+			path, err := writeAsset(c.opts.AssetsDir, data, contentType)
+			if err != nil {
+				return err
+			}
+			c.images[id] = path
+		default:
+			c.images[id] = uri
+		}
+	}
+
+	return nil
+}
+
+// inlineImage builds an inline image, or returns nil if the object is not
+// an image.
+func (c *Converter) inlineImage(element *docs.InlineObjectElement) *document.Image {
+	obj, ok := c.inlineObjects[element.InlineObjectId]
+	if !ok || obj.InlineObjectProperties == nil {
+		return nil
+	}
+	return c.image(element.InlineObjectId, obj.InlineObjectProperties.EmbeddedObject)
+}
+
+// positionedImages builds the images positioned relative to a paragraph,
+// one paragraph per image.
+func (c *Converter) positionedImages(ids []string) []document.Block {
+	var blocks []document.Block
+	for _, id := range ids {
+		obj, ok := c.positionedObjects[id]
+		if !ok || obj.PositionedObjectProperties == nil {
+			continue
+		}
+		if image := c.image(id, obj.PositionedObjectProperties.EmbeddedObject); image != nil {
+			blocks = append(blocks, document.Block{Paragraph: &document.Paragraph{
+				Inlines: []document.Inline{{Image: image}},
+			}})
+		}
+	}
+	return blocks
+}
+
+// image builds an image using the object's title and description as alt
+// text.
+func (c *Converter) image(id string, obj *docs.EmbeddedObject) *document.Image {
+	target, ok := c.images[id]
+	if !ok {
+		// Images have not been prepared, e.g. when converting a single
+		// paragraph, so link to the content URI directly
+		target = imageURI(obj)
+	}
+	if target == "" {
+		return nil
+	}
+	return &document.Image{Src: target, Alt: imageAltText(obj)}
+}
+
+// renderImage renders a markdown image.
+func renderImage(image *document.Image) string {
+	alt := strings.ReplaceAll(image.Alt, "[", "\\[")
+	alt = strings.ReplaceAll(alt, "]", "\\]")
+	return "![" + alt + "](" + image.Src + ")"
+}
+
+// imageURI returns the content URI of an embedded image, or an empty
+// string if the object is not an image.
+func imageURI(obj *docs.EmbeddedObject) string {
+	if obj == nil || obj.ImageProperties == nil {
+		return ""
+	}
+	return obj.ImageProperties.ContentUri
+}
+
+// imageAltText builds alt text from an embedded object's title and description.
+func imageAltText(obj *docs.EmbeddedObject) string {
+	var parts []string
+	for _, s := range []string{obj.Title, obj.Description} {
+		s = strings.Join(strings.Fields(s), " ")
+		if s != "" && (len(parts) == 0 || parts[0] != s) {
+			parts = append(parts, s)
+		}
+	}
+	return strings.Join(parts, ": ")
+}
+
+// writeAsset writes image data to dir using a content-hash filename and
+// returns the path to use in links.
+func writeAsset(dir string, data []byte, contentType string) (string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create assets directory: %w", err)
+	}
+
+	sum := sha256.Sum256(data)
+	name := hex.EncodeToString(sum[:8]) + imageExtension(contentType)
+	path := filepath.Join(dir, name)
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return "", fmt.Errorf("failed to write image %s: %w", path, err)
+	}
+
+	return filepath.ToSlash(path), nil
+}
+
+// imageExtension returns the file extension for an image content type.
+func imageExtension(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return ""
+	}
+	switch mediaType {
+	case "image/png":
+		return ".png"
+	case "image/jpeg":
+		return ".jpg"
+	case "image/gif":
+		return ".gif"
+	case "image/svg+xml":
+		return ".svg"
+	case "image/webp":
+		return ".webp"
+	}
+	if exts, err := mime.ExtensionsByType(mediaType); err == nil && len(exts) > 0 {
+		return exts[0]
+	}
+	return ""
+}
+
+// dataURI encodes image data as a base64 data URI.
+func dataURI(data []byte, contentType string) string {
+	return "data:" + contentType + ";base64," + base64.StdEncoding.EncodeToString(data)
+}
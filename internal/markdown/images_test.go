@@ -0,0 +1,147 @@
+package markdown
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/docs/v1"
+)
+
+func imageDocument() *docs.Document {
+	return &docs.Document{
+		Title: "Images",
+		Body: &docs.Body{
+			Content: []*docs.StructuralElement{
+				{
+					Paragraph: &docs.Paragraph{
+						Elements: []*docs.ParagraphElement{
+							{TextRun: &docs.TextRun{Content: "See "}},
+							{InlineObjectElement: &docs.InlineObjectElement{InlineObjectId: "kix.inline"}},
+							{TextRun: &docs.TextRun{Content: "\n"}},
+						},
+						PositionedObjectIds: []string{"kix.positioned"},
+					},
+				},
+			},
+		},
+		InlineObjects: map[string]docs.InlineObject{
+			"kix.inline": {
+				InlineObjectProperties: &docs.InlineObjectProperties{
+					EmbeddedObject: &docs.EmbeddedObject{
+						Title:           "Architecture",
+						Description:     "Service overview [v2]",
+						ImageProperties: &docs.ImageProperties{ContentUri: "https://lh.example.com/inline"},
+					},
+				},
+			},
+		},
+		PositionedObjects: map[string]docs.PositionedObject{
+			"kix.positioned": {
+				PositionedObjectProperties: &docs.PositionedObjectProperties{
+					EmbeddedObject: &docs.EmbeddedObject{
+						Description:     "Logo",
+						ImageProperties: &docs.ImageProperties{ContentUri: "https://lh.example.com/positioned"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestConvertImages(t *testing.T) {
+	fetch := func(uri string) ([]byte, string, error) {
+		switch uri {
+		case "https://lh.example.com/inline":
+			return []byte("inline-bytes"), "image/png", nil
+		case "https://lh.example.com/positioned":
+			return []byte("positioned-bytes"), "image/jpeg", nil
+		}
+		return nil, "", errors.New("unexpected uri")
+	}
+
+	t.Run("remote", func(t *testing.T) {
+		c := NewConverter(imageDocument())
+		got, err := c.Convert()
+		if err != nil {
+			t.Fatalf("Convert() error = %v", err)
+		}
+		want := "See ![Architecture: Service overview \\[v2\\]](https://lh.example.com/inline)\n\n" +
+			"![Logo](https://lh.example.com/positioned)\n\n"
+		if !strings.HasSuffix(got, want) {
+			t.Errorf("Convert() = %q, want suffix %q", got, want)
+		}
+	})
+
+	t.Run("files", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "assets")
+		c := NewConverter(imageDocument())
+		c.SetOptions(Options{Images: ImagesFiles, AssetsDir: dir, FetchImage: fetch})
+		got, err := c.Convert()
+		if err != nil {
+			t.Fatalf("Convert() error = %v", err)
+		}
+
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			t.Fatalf("ReadDir() error = %v", err)
+		}
+		if len(entries) != 2 {
+			t.Fatalf("expected 2 assets, got %d", len(entries))
+		}
+		for _, entry := range entries {
+			path := filepath.ToSlash(filepath.Join(dir, entry.Name()))
+			if !strings.Contains(got, "]("+path+")") {
+				t.Errorf("output does not link to %s: %q", path, got)
+			}
+		}
+	})
+
+	t.Run("inline", func(t *testing.T) {
+		c := NewConverter(imageDocument())
+		c.SetOptions(Options{Images: ImagesInline, FetchImage: fetch})
+		got, err := c.Convert()
+		if err != nil {
+			t.Fatalf("Convert() error = %v", err)
+		}
+		if !strings.Contains(got, "](data:image/png;base64,aW5saW5lLWJ5dGVz)") {
+			t.Errorf("Convert() missing inline data URI: %q", got)
+		}
+		if !strings.Contains(got, "![Logo](data:image/jpeg;base64,cG9zaXRpb25lZC1ieXRlcw==)") {
+			t.Errorf("Convert() missing positioned data URI: %q", got)
+		}
+	})
+
+	t.Run("download error", func(t *testing.T) {
+		c := NewConverter(imageDocument())
+		c.SetOptions(Options{
+			Images: ImagesInline,
+			FetchImage: func(string) ([]byte, string, error) {
+				return nil, "", errors.New("forbidden")
+			},
+		})
+		if _, err := c.Convert(); err == nil {
+			t.Error("Convert() expected error, got nil")
+		}
+	})
+}
+
+func TestWriteAssetContentHash(t *testing.T) {
+	dir := t.TempDir()
+	first, err := writeAsset(dir, []byte("same"), "image/png")
+	if err != nil {
+		t.Fatalf("writeAsset() error = %v", err)
+	}
+	second, err := writeAsset(dir, []byte("same"), "image/png")
+	if err != nil {
+		t.Fatalf("writeAsset() error = %v", err)
+	}
+	if first != second {
+		t.Errorf("same content produced different paths: %q and %q", first, second)
+	}
+	if filepath.Ext(first) != ".png" {
+		t.Errorf("writeAsset() extension = %q, want .png", filepath.Ext(first))
+	}
+}
@@ -0,0 +1,82 @@
+package markdown
+
+import (
+	"path"
+	"strings"
+
+	"github.com/famasya/gdocs-cli/internal/gdocs"
+)
+
+// rewriteDocumentLink points a link to another exported document at its
+// markdown file, relative to this document's output path. The tab query
+// and heading fragment of the link are kept. Links to documents outside
+// the export are returned unchanged.
+func (c *Converter) rewriteDocumentLink(url string) string {
+	if len(c.opts.DocumentPaths) == 0 {
+		return url
+	}
+
+	docID, err := gdocs.ExtractDocumentID(url)
+	if err != nil {
+		return url
+	}
+	target, ok := c.opts.DocumentPaths[docID]
+	if !ok {
+		return url
+	}
+
+	tabID := gdocs.ExtractTabID(url)
+	fragment := ""
+	if i := strings.Index(url, "#"); i >= 0 {
+		fragment = url[i:]
+	}
+
+	// Links to a heading in this tab of this document stay in the file
+	if c.doc != nil && docID == c.doc.DocumentId && (tabID == "" || tabID == c.tabID) {
+		headingID, _ := parseDocsFragment(fragment)
+		if anchor, ok := c.headingAnchor(headingID); ok {
+			return "#" + anchor
+		}
+	}
+
+	link := relativePath(c.outputPath(), markdownPath(target))
+	if tabID != "" {
+		link += "?tab=" + tabID
+	}
+	return link + fragment
+}
+
+// outputPath returns the path this document is written to, from its own
+// entry in the document paths.
+func (c *Converter) outputPath() string {
+	if c.doc == nil {
+		return ""
+	}
+	return c.opts.DocumentPaths[c.doc.DocumentId]
+}
+
+// markdownPath adds the .md extension to paths without an extension.
+func markdownPath(p string) string {
+	if path.Ext(p) == "" {
+		return p + ".md"
+	}
+	return p
+}
+
+// relativePath returns target relative to the directory of the file at
+// from. Both are slash-separated paths relative to the same root.
+func relativePath(from, target string) string {
+	fromDir := strings.Split(path.Dir(path.Clean(from)), "/")
+	parts := strings.Split(path.Clean(target), "/")
+	if fromDir[0] == "." {
+		fromDir = nil
+	}
+
+	common := 0
+	for common < len(fromDir) && common < len(parts)-1 && fromDir[common] == parts[common] {
+		common++
+	}
+
+	up := strings.Repeat("../", len(fromDir)-common)
+	return up + strings.Join(parts[common:], "/")
+}
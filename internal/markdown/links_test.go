@@ -0,0 +1,87 @@
+package markdown
+
+import (
+	"testing"
+
+	"google.golang.org/api/docs/v1"
+)
+
+func TestRelativePath(t *testing.T) {
+	tests := []struct {
+		from, target string
+		want         string
+	}{
+		{"", "guide.md", "guide.md"},
+		{"index.md", "docs/guide.md", "docs/guide.md"},
+		{"docs/index.md", "docs/guide.md", "guide.md"},
+		{"docs/api/index.md", "docs/guide.md", "../guide.md"},
+		{"docs/index.md", "specs/design.md", "../specs/design.md"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.from+"->"+tt.target, func(t *testing.T) {
+			if got := relativePath(tt.from, tt.target); got != tt.want {
+				t.Errorf("relativePath(%q, %q) = %q, want %q", tt.from, tt.target, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRewriteDocumentLinks(t *testing.T) {
+	overview := headingParagraph("HEADING_1", "Overview")
+	overview.Paragraph.ParagraphStyle.HeadingId = "h.abc123"
+
+	tests := []struct {
+		name  string
+		url   string
+		paths map[string]string
+		want  string
+	}{
+		{
+			name:  "exported document",
+			url:   "https://docs.google.com/document/d/doc2/edit",
+			paths: map[string]string{"doc1": "docs/index.md", "doc2": "docs/specs/design"},
+			want:  "[see](specs/design.md)",
+		},
+		{
+			name:  "tab and heading",
+			url:   "https://docs.google.com/document/d/doc2/edit?tab=t.1#heading=h.xyz",
+			paths: map[string]string{"doc1": "docs/index.md", "doc2": "guide.md"},
+			want:  "[see](../guide.md?tab=t.1#heading=h.xyz)",
+		},
+		{
+			name:  "heading in this document",
+			url:   "https://docs.google.com/document/d/doc1/edit#heading=h.abc123",
+			paths: map[string]string{"doc1": "index.md"},
+			want:  "[see](#overview)",
+		},
+		{
+			name:  "document outside the export",
+			url:   "https://docs.google.com/document/d/doc3/edit",
+			paths: map[string]string{"doc2": "guide.md"},
+			want:  "[see](https://docs.google.com/document/d/doc3/edit)",
+		},
+		{
+			name: "no document paths",
+			url:  "https://docs.google.com/document/d/doc2/edit",
+			want: "[see](https://docs.google.com/document/d/doc2/edit)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := &docs.Document{
+				DocumentId: "doc1",
+				Body: &docs.Body{Content: []*docs.StructuralElement{
+					overview, linkedParagraph("see", &docs.Link{Url: tt.url}),
+				}},
+			}
+			c := NewConverter(doc)
+			c.SetOptions(Options{DocumentPaths: tt.paths})
+			want := "# Overview\n\n" + tt.want + "\n\n"
+			if got := convertBody(t, c); got != want {
+				t.Errorf("convertBody() = %q, want %q", got, want)
+			}
+		})
+	}
+}
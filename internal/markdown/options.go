@@ -0,0 +1,190 @@
+package markdown
+
+// ImageMode controls how images embedded in a document are exported.
+type ImageMode string
+
+const (
+	// ImagesRemote links to the image's content URI returned by the API.
+	// These URIs are only valid for a short time after the request.
+	ImagesRemote ImageMode = "remote"
+	// ImagesFiles downloads images into Options.AssetsDir.
+	ImagesFiles ImageMode = "files"
+	// ImagesInline embeds images as base64 data URIs.
+	ImagesInline ImageMode = "inline"
+)
+
+// HeaderFooterMode controls whether page headers and footers are rendered.
+type HeaderFooterMode string
+
+const (
+	// HeaderFooterNone omits headers and footers.
+	HeaderFooterNone HeaderFooterMode = "none"
+	// HeaderFooterSections renders headers before and footers after the
+	// body, delimited by HTML comments.
+	HeaderFooterSections HeaderFooterMode = "sections"
+	// HeaderFooterFrontmatter adds header and footer text to the frontmatter.
+	HeaderFooterFrontmatter HeaderFooterMode = "frontmatter"
+)
+
+// BreakMode controls how horizontal rules and page breaks are rendered.
+type BreakMode string
+
+const (
+	// BreakRule renders a markdown thematic break (---).
+	BreakRule BreakMode = "rule"
+	// BreakHTML renders an HTML element: <hr> for horizontal rules and a
+	// div with page-break-after for page breaks.
+	BreakHTML BreakMode = "html"
+	// BreakNone omits the break.
+	BreakNone BreakMode = "none"
+)
+
+// TOCMode controls how a table of contents in the document is rendered.
+type TOCMode string
+
+const (
+	// TOCGenerate renders a list of links to the document's headings.
+	TOCGenerate TOCMode = "generate"
+	// TOCDrop omits the table of contents.
+	TOCDrop TOCMode = "drop"
+)
+
+// AnchorMode controls how heading anchors are written.
+type AnchorMode string
+
+const (
+	// AnchorsGitHub relies on the anchors GitHub-style renderers derive
+	// from the heading text, so headings are written as is.
+	AnchorsGitHub AnchorMode = "github"
+	// AnchorsExplicit appends the anchor to each heading as a {#id}
+	// attribute.
+	AnchorsExplicit AnchorMode = "explicit"
+)
+
+// Flavor selects the markdown dialect the output is written for, which
+// decides how features outside CommonMark are written.
+type Flavor string
+
+const (
+	// FlavorGFM writes GitHub Flavored Markdown: pipe tables,
+	// ~~strikethrough~~, task lists and [^n] footnotes.
+	FlavorGFM Flavor = "gfm"
+	// FlavorCommonMark writes strict CommonMark, using inline HTML for
+	// tables, strikethrough, task lists, footnotes and explicit heading
+	// anchors.
+	FlavorCommonMark Flavor = "commonmark"
+	// FlavorObsidian writes GFM with [[wikilinks]] for links to headings
+	// and to other exported documents, and ==highlight== for highlighted
+	// text.
+	FlavorObsidian Flavor = "obsidian"
+	// FlavorPandoc writes Pandoc markdown: grid tables, which hold merged
+	// cells and block content, and a {#id} attribute on every heading.
+	FlavorPandoc Flavor = "pandoc"
+)
+
+// BlockquoteRules selects which paragraphs are rendered as blockquotes.
+// The zero value renders no blockquotes.
+type BlockquoteRules struct {
+	// Indent quotes paragraphs indented from the left margin, one level
+	// of nesting per IndentStep.
+	Indent bool
+	// IndentStep is the indentation of one quote level in points.
+	// Defaults to 36, the step of the Docs indent buttons.
+	IndentStep float64
+
+	// FontFamily quotes paragraphs whose text is all in this font.
+	FontFamily string
+	// Border quotes paragraphs with a left border.
+	Border bool
+	// Shading quotes paragraphs with a background color.
+	Shading bool
+}
+
+// ImageFetcher downloads the image at uri and returns its bytes and
+// content type.
+type ImageFetcher func(uri string) (data []byte, contentType string, err error)
+
+// Options controls how a document is converted.
+// The zero value produces the default output.
+type Options struct {
+	// Images selects how images are exported. Defaults to ImagesRemote.
+	Images ImageMode
+	// AssetsDir is the directory images are written to in ImagesFiles
+	// mode. Image links use this path as given.
+	AssetsDir string
+	// FetchImage downloads image content. Required for ImagesFiles and
+	// ImagesInline.
+	FetchImage ImageFetcher
+
+	// HeadersFooters selects how page headers and footers are rendered.
+	// Defaults to HeaderFooterNone.
+	HeadersFooters HeaderFooterMode
+
+	// CodeLanguage is the language hint added to fenced code blocks.
+	CodeLanguage string
+
+	// Flavor selects the markdown dialect. Defaults to FlavorGFM.
+	Flavor Flavor
+
+	// RawText disables escaping of markdown syntax in document text.
+	RawText bool
+
+	// RichInline renders underline, superscript, subscript, highlight and
+	// text color as inline HTML.
+	RichInline bool
+
+	// CriticMarkup renders suggested insertions and deletions as
+	// CriticMarkup {++ ++} and {-- --}. Use with documents fetched with
+	// suggestions inline.
+	CriticMarkup bool
+	// SuggestionAuthors maps suggestion IDs to author names, which are
+	// added as CriticMarkup comments. The Docs API does not return
+	// suggestion authors, so callers must supply them.
+	SuggestionAuthors map[string]string
+
+	// PlainMentions renders person chips as names without mailto links.
+	PlainMentions bool
+
+	// HorizontalRules and PageBreaks select how horizontal rules and page
+	// breaks (including next-page section breaks) are rendered. Both
+	// default to BreakRule.
+	HorizontalRules BreakMode
+	PageBreaks      BreakMode
+
+	// TableOfContents selects how a table of contents is rendered.
+	// Defaults to TOCGenerate.
+	TableOfContents TOCMode
+
+	// HeadingAnchors selects how heading anchors are written. Links to
+	// headings in the document point to these anchors either way. The
+	// Pandoc and CommonMark flavors always write them. Defaults to
+	// AnchorsGitHub.
+	HeadingAnchors AnchorMode
+
+	// DocumentPaths maps the IDs of documents exported together to the
+	// slash-separated paths of their markdown files. Links to these
+	// documents become relative links, keeping the tab and heading of
+	// the link. The converted document's own entry, if any, is the path
+	// links are relative to.
+	DocumentPaths map[string]string
+
+	// Blockquotes selects the paragraphs rendered as blockquotes.
+	Blockquotes BlockquoteRules
+
+	// HeadingLevels overrides the markdown heading level of paragraph
+	// styles (TITLE, SUBTITLE and HEADING_1 to HEADING_6). By default
+	// the title is level 1, the subtitle level 2 and HEADING_n level n.
+	HeadingLevels map[string]int
+	// HeadingShift demotes all headings by this many levels, for
+	// embedding the output under a heading of another document.
+	// Headings never go deeper than level 6.
+	HeadingShift int
+	// DropTitle omits the title paragraph. The title is still written
+	// to the frontmatter.
+	DropTitle bool
+	// SubtitleEmphasis renders the subtitle as emphasized text instead
+	// of a heading.
+	SubtitleEmphasis bool
+	// NumberHeadings numbers headings hierarchically (1, 1.1, 1.1.1).
+	NumberHeadings bool
+}
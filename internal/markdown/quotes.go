@@ -0,0 +1,193 @@
+package markdown
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/famasya/gdocs-cli/internal/document"
+	"google.golang.org/api/docs/v1"
+)
+
+// defaultQuoteIndentStep is the indentation of one quote level in points.
+const defaultQuoteIndentStep = 36
+
+// ParseBlockquoteRules parses a comma-separated list of blockquote
+// criteria: indent, border, shading and font=NAME.
+func ParseBlockquoteRules(spec string) (BlockquoteRules, error) {
+	var rules BlockquoteRules
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		switch {
+		case item == "":
+		case item == "indent":
+			rules.Indent = true
+		case item == "border":
+			rules.Border = true
+		case item == "shading":
+			rules.Shading = true
+		case strings.HasPrefix(item, "font="):
+			rules.FontFamily = strings.TrimPrefix(item, "font=")
+		default:
+			return BlockquoteRules{}, fmt.Errorf("invalid blockquote rule '%s': expected indent, border, shading or font=NAME", item)
+		}
+	}
+	return rules, nil
+}
+
+// quoteDepth returns how deeply a paragraph is quoted, or 0 if it is not
+// a blockquote. Indentation gives one level per indent step; matching the
+// style criteria quotes the paragraph at least one level deep.
+func (c *Converter) quoteDepth(paragraph *docs.Paragraph) int {
+	rules := c.opts.Blockquotes
+	style := paragraph.ParagraphStyle
+	if paragraph.Bullet != nil || style == nil || isHeadingStyle(style) {
+		return 0
+	}
+
+	depth := 0
+	if rules.Indent {
+		step := rules.IndentStep
+		if step <= 0 {
+			step = defaultQuoteIndentStep
+		}
+		depth = int(math.Round(paragraphIndent(style) / step))
+	}
+
+	styled := (rules.Border && hasLeftBorder(style)) ||
+		(rules.Shading && hasShading(style)) ||
+		(rules.FontFamily != "" && isInFont(paragraph, rules.FontFamily))
+	if styled {
+		depth = max(depth, 1)
+	}
+	return depth
+}
+
+// paragraphIndent returns how far all lines of a paragraph are indented,
+// so a first-line indent alone does not make a quote.
+func paragraphIndent(style *docs.ParagraphStyle) float64 {
+	if style.IndentStart == nil {
+		return 0
+	}
+	indent := style.IndentStart.Magnitude
+	if style.IndentFirstLine != nil {
+		indent = min(indent, style.IndentFirstLine.Magnitude)
+	}
+	return indent
+}
+
+// hasLeftBorder reports whether a paragraph has a visible left border.
+func hasLeftBorder(style *docs.ParagraphStyle) bool {
+	border := style.BorderLeft
+	return border != nil && border.Width != nil && border.Width.Magnitude > 0
+}
+
+// hasShading reports whether a paragraph has a background color other
+// than white.
+func hasShading(style *docs.ParagraphStyle) bool {
+	if style.Shading == nil || style.Shading.BackgroundColor == nil {
+		return false
+	}
+	color := hexColor(style.Shading.BackgroundColor)
+	return color != "" && color != "#ffffff"
+}
+
+// isInFont reports whether all text of a paragraph is in the font family.
+func isInFont(paragraph *docs.Paragraph, family string) bool {
+	hasText := false
+	for _, element := range paragraph.Elements {
+		run := element.TextRun
+		if run == nil || strings.TrimSpace(run.Content) == "" {
+			continue
+		}
+		style := run.TextStyle
+		if style == nil || style.WeightedFontFamily == nil || !strings.EqualFold(style.WeightedFontFamily.FontFamily, family) {
+			return false
+		}
+		hasText = true
+	}
+	return hasText
+}
+
+// blockquoteLength returns the number of leading elements that are quoted
+// paragraphs, or 0 if content does not start with one.
+func (c *Converter) blockquoteLength(content []*docs.StructuralElement) int {
+	n := 0
+	for _, element := range content {
+		if element.Paragraph == nil || c.quoteDepth(element.Paragraph) == 0 {
+			break
+		}
+		n++
+	}
+	return n
+}
+
+// buildBlockquote builds consecutive quoted paragraphs as one blockquote,
+// nesting paragraphs by their quote depth, followed by the paragraphs'
+// positioned images.
+func (c *Converter) buildBlockquote(content []*docs.StructuralElement) []document.Block {
+	quote := &document.Blockquote{}
+	// open holds the quote at each depth, outermost first
+	open := []*document.Blockquote{quote}
+	for _, element := range content {
+		paragraph := element.Paragraph
+		depth := c.quoteDepth(paragraph)
+		open = open[:min(depth, len(open))]
+		for len(open) < depth {
+			nested := &document.Blockquote{}
+			parent := open[len(open)-1]
+			parent.Blocks = append(parent.Blocks, document.Block{Blockquote: nested})
+			open = append(open, nested)
+		}
+		if block, ok := c.buildParagraph(paragraph, paragraph.ParagraphStyle); ok {
+			open[depth-1].Blocks = append(open[depth-1].Blocks, block)
+		}
+	}
+
+	blocks := []document.Block{{Blockquote: quote}}
+	for _, element := range content {
+		blocks = append(blocks, c.positionedImages(element.Paragraph.PositionedObjectIds)...)
+	}
+	return blocks
+}
+
+// renderBlockquote renders a blockquote, separating its paragraphs by
+// lines quoted as deep as the quote they share.
+func (r *Renderer) renderBlockquote(quote *document.Blockquote) string {
+	text := r.quoteText(quote)
+	if text == "" {
+		return ""
+	}
+	return text + "\n\n"
+}
+
+// quoteText renders the content of a blockquote with its markers.
+func (r *Renderer) quoteText(quote *document.Blockquote) string {
+	var parts []string
+	for _, block := range quote.Blocks {
+		var text string
+		if block.Blockquote != nil {
+			text = r.quoteText(block.Blockquote)
+		} else {
+			text = strings.TrimRight(r.renderBlock(block), "\n")
+		}
+		if text == "" {
+			continue
+		}
+		lines := strings.Split(text, "\n")
+		for i, line := range lines {
+			if line == "" {
+				lines[i] = ">"
+			} else {
+				lines[i] = quotePrefix(1) + line
+			}
+		}
+		parts = append(parts, strings.Join(lines, "\n"))
+	}
+	return strings.Join(parts, "\n>\n")
+}
+
+// quotePrefix returns the blockquote markers for a quote depth.
+func quotePrefix(depth int) string {
+	return strings.Repeat("> ", depth)
+}
@@ -0,0 +1,128 @@
+package markdown
+
+import (
+	"testing"
+
+	"google.golang.org/api/docs/v1"
+)
+
+func TestParseBlockquoteRules(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    BlockquoteRules
+		wantErr bool
+	}{
+		{spec: "", want: BlockquoteRules{}},
+		{spec: "indent", want: BlockquoteRules{Indent: true}},
+		{spec: "indent, border,shading", want: BlockquoteRules{Indent: true, Border: true, Shading: true}},
+		{spec: "font=Georgia", want: BlockquoteRules{FontFamily: "Georgia"}},
+		{spec: "italic", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			got, err := ParseBlockquoteRules(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseBlockquoteRules(%q) error = %v, wantErr %v", tt.spec, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseBlockquoteRules(%q) = %+v, want %+v", tt.spec, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvertBlockquotes(t *testing.T) {
+	pt := func(v float64) *docs.Dimension { return &docs.Dimension{Magnitude: v, Unit: "PT"} }
+	paragraph := func(text string, style *docs.ParagraphStyle) *docs.StructuralElement {
+		return &docs.StructuralElement{Paragraph: &docs.Paragraph{
+			Elements:       []*docs.ParagraphElement{{TextRun: &docs.TextRun{Content: text + "\n"}}},
+			ParagraphStyle: style,
+		}}
+	}
+	indented := func(text string, start float64) *docs.StructuralElement {
+		return paragraph(text, &docs.ParagraphStyle{IndentStart: pt(start), IndentFirstLine: pt(start)})
+	}
+	georgia := &docs.StructuralElement{Paragraph: &docs.Paragraph{
+		Elements: []*docs.ParagraphElement{{TextRun: &docs.TextRun{
+			Content:   "Quoted in Georgia\n",
+			TextStyle: &docs.TextStyle{WeightedFontFamily: &docs.WeightedFontFamily{FontFamily: "Georgia"}},
+		}}},
+		ParagraphStyle: &docs.ParagraphStyle{},
+	}}
+
+	tests := []struct {
+		name    string
+		rules   BlockquoteRules
+		content []*docs.StructuralElement
+		want    string
+	}{
+		{
+			name:    "disabled",
+			content: []*docs.StructuralElement{indented("Quote", 36)},
+			want:    "Quote\n\n",
+		},
+		{
+			name:  "indented paragraphs",
+			rules: BlockquoteRules{Indent: true},
+			content: []*docs.StructuralElement{
+				paragraph("Before", nil),
+				indented("First", 36),
+				indented("Second", 36),
+				paragraph("After", nil),
+			},
+			want: "Before\n\n> First\n>\n> Second\n\nAfter\n\n",
+		},
+		{
+			name:  "nested indentation",
+			rules: BlockquoteRules{Indent: true},
+			content: []*docs.StructuralElement{
+				indented("Outer", 36),
+				indented("Inner", 72),
+				indented("Outer again", 36),
+			},
+			want: "> Outer\n>\n> > Inner\n>\n> Outer again\n\n",
+		},
+		{
+			name:  "first line indent is not a quote",
+			rules: BlockquoteRules{Indent: true},
+			content: []*docs.StructuralElement{
+				paragraph("Prose", &docs.ParagraphStyle{IndentStart: pt(0), IndentFirstLine: pt(36)}),
+			},
+			want: "Prose\n\n",
+		},
+		{
+			name:  "left border",
+			rules: BlockquoteRules{Border: true},
+			content: []*docs.StructuralElement{
+				paragraph("Bordered", &docs.ParagraphStyle{BorderLeft: &docs.ParagraphBorder{Width: pt(3)}}),
+			},
+			want: "> Bordered\n\n",
+		},
+		{
+			name:  "shading",
+			rules: BlockquoteRules{Shading: true},
+			content: []*docs.StructuralElement{
+				paragraph("Shaded", &docs.ParagraphStyle{Shading: &docs.Shading{BackgroundColor: rgb(0.9, 0.9, 0.9)}}),
+				paragraph("White", &docs.ParagraphStyle{Shading: &docs.Shading{BackgroundColor: rgb(1, 1, 1)}}),
+			},
+			want: "> Shaded\n\nWhite\n\n",
+		},
+		{
+			name:    "font",
+			rules:   BlockquoteRules{FontFamily: "georgia"},
+			content: []*docs.StructuralElement{georgia},
+			want:    "> Quoted in Georgia\n\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewConverter(&docs.Document{Body: &docs.Body{Content: tt.content}})
+			c.SetOptions(Options{Blockquotes: tt.rules})
+			if got := convertBody(t, c); got != tt.want {
+				t.Errorf("convertBody() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package markdown
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/famasya/gdocs-cli/internal/document"
+)
+
+// Renderer renders a document tree as markdown.
+type Renderer struct {
+	opts Options
+
+	// headings maps heading anchors to their text while rendering for
+	// Obsidian, which links to headings by text
+	headings map[string]string
+}
+
+// NewRenderer creates a markdown renderer. Options that affect how the
+// tree is built, such as image handling or heading levels, are ignored.
+func NewRenderer(opts Options) *Renderer {
+	return &Renderer{opts: opts}
+}
+
+// Render implements document.Renderer.
+func (r *Renderer) Render(doc *document.Document) (string, error) {
+	var builder strings.Builder
+
+	if r.opts.Flavor == FlavorObsidian {
+		r.headings = headingTexts(doc.Blocks)
+	}
+
+	// Generate frontmatter
+	frontmatter, err := renderFrontmatter(doc.Metadata)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate frontmatter: %w", err)
+	}
+	builder.WriteString(frontmatter)
+	builder.WriteString("\n")
+
+	builder.WriteString(r.renderSections(doc.Headers))
+	builder.WriteString(r.renderBlocks(doc.Blocks))
+	builder.WriteString(r.renderSections(doc.Footers))
+
+	// Append definitions for the footnotes referenced in the body
+	if footnotes := r.renderFootnotes(doc.Footnotes); footnotes != "" {
+		builder.WriteString("\n")
+		builder.WriteString(footnotes)
+	}
+
+	// Append comments if present
+	if len(doc.Comments) > 0 {
+		builder.WriteString("\n")
+		builder.WriteString(renderComments(doc.Comments))
+	}
+
+	return builder.String(), nil
+}
+
+// renderBlocks renders a sequence of blocks.
+func (r *Renderer) renderBlocks(blocks []document.Block) string {
+	return strings.Join(r.renderBlockParts(blocks), "")
+}
+
+// renderBlockParts renders each block of a sequence separately, for
+// callers that need to know where a block starts in the output.
+func (r *Renderer) renderBlockParts(blocks []document.Block) []string {
+	parts := make([]string, len(blocks))
+	for i, block := range blocks {
+		parts[i] = r.renderBlock(block)
+
+		// End a list with a blank line so following content is not
+		// treated as a continuation of its last item. A list directly
+		// after it would still be joined to it, so an empty comment
+		// separates the two.
+		if block.List != nil && i+1 < len(blocks) {
+			parts[i] += "\n"
+			if blocks[i+1].List != nil {
+				parts[i] += "<!-- -->\n\n"
+			}
+		}
+	}
+	return parts
+}
+
+// renderBlock renders a single block.
+func (r *Renderer) renderBlock(block document.Block) string {
+	switch {
+	case block.Paragraph != nil:
+		return r.renderParagraph(block.Paragraph)
+	case block.Heading != nil:
+		return r.renderHeading(block.Heading)
+	case block.List != nil:
+		return r.renderList(block.List, "")
+	case block.Table != nil:
+		return r.renderTable(block.Table)
+	case block.CodeBlock != nil:
+		return renderCodeBlock(block.CodeBlock)
+	case block.Blockquote != nil:
+		return r.renderBlockquote(block.Blockquote)
+	case block.Break != nil:
+		return r.renderBreak(block.Break)
+	case block.TableOfContents != nil:
+		return r.renderTableOfContents(block.TableOfContents)
+	}
+	return ""
+}
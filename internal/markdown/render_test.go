@@ -0,0 +1,123 @@
+package markdown
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/famasya/gdocs-cli/internal/document"
+	"google.golang.org/api/docs/v1"
+)
+
+// convertBody converts a document to markdown and returns the output
+// after the frontmatter.
+func convertBody(t *testing.T, c *Converter) string {
+	t.Helper()
+	output, err := c.Convert()
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	_, body, ok := strings.Cut(strings.TrimPrefix(output, "---\n"), "\n---\n\n")
+	if !ok {
+		t.Fatalf("Convert() = %q, want frontmatter", output)
+	}
+	return body
+}
+
+// convertContent converts structural elements as the body of the
+// converter's document.
+func convertContent(t *testing.T, c *Converter, content []*docs.StructuralElement) string {
+	t.Helper()
+	c.body = &docs.Body{Content: content}
+	return convertBody(t, c)
+}
+
+// convertElements converts paragraph elements as the only paragraph of
+// the converter's document, without the paragraph's trailing newlines.
+func convertElements(t *testing.T, c *Converter, elements []*docs.ParagraphElement) string {
+	t.Helper()
+	content := []*docs.StructuralElement{{Paragraph: &docs.Paragraph{Elements: elements}}}
+	return strings.TrimRight(convertContent(t, c, content), "\n")
+}
+
+func TestDocumentTree(t *testing.T) {
+	bullet := func(level int64, text string) *docs.StructuralElement {
+		return &docs.StructuralElement{Paragraph: &docs.Paragraph{
+			Elements: []*docs.ParagraphElement{{TextRun: &docs.TextRun{Content: text + "\n"}}},
+			Bullet:   &docs.Bullet{ListId: "l1", NestingLevel: level},
+		}}
+	}
+	text := func(value string) []document.Inline {
+		return []document.Inline{{Text: &document.Text{Value: value}}}
+	}
+
+	doc := &docs.Document{
+		Title: "Notes",
+		Body: &docs.Body{Content: []*docs.StructuralElement{
+			headingParagraph("HEADING_1", "Plan"),
+			{Paragraph: &docs.Paragraph{Elements: []*docs.ParagraphElement{
+				{TextRun: &docs.TextRun{Content: "Line one\vline "}},
+				{TextRun: &docs.TextRun{Content: "two", TextStyle: &docs.TextStyle{Bold: true}}},
+				{TextRun: &docs.TextRun{Content: "\n"}},
+			}}},
+			bullet(0, "First"),
+			bullet(1, "Nested"),
+			bullet(0, "Second"),
+			{Paragraph: &docs.Paragraph{Elements: []*docs.ParagraphElement{
+				{HorizontalRule: &docs.HorizontalRule{}},
+				{TextRun: &docs.TextRun{Content: "\n"}},
+			}}},
+		}},
+		Lists: map[string]docs.List{"l1": {ListProperties: &docs.ListProperties{NestingLevels: []*docs.NestingLevel{
+			{GlyphType: "DECIMAL"},
+			{GlyphSymbol: "●"},
+		}}}},
+	}
+
+	got, err := NewConverter(doc).Document()
+	if err != nil {
+		t.Fatalf("Document() error = %v", err)
+	}
+
+	want := []document.Block{
+		{Heading: &document.Heading{Level: 1, Style: "HEADING_1", Anchor: "plan", Inlines: text("Plan")}},
+		{Paragraph: &document.Paragraph{Inlines: []document.Inline{
+			{Text: &document.Text{Value: "Line one"}},
+			{LineBreak: true},
+			{Text: &document.Text{Value: "line "}},
+			{Text: &document.Text{Value: "two", TextStyle: document.TextStyle{Bold: true}}},
+		}}},
+		{List: &document.List{Ordered: true, Items: []document.ListItem{
+			{Number: 1, Blocks: []document.Block{
+				{Paragraph: &document.Paragraph{Inlines: text("First")}},
+				{List: &document.List{Items: []document.ListItem{
+					{Blocks: []document.Block{{Paragraph: &document.Paragraph{Inlines: text("Nested")}}}},
+				}}},
+			}},
+			{Number: 2, Blocks: []document.Block{{Paragraph: &document.Paragraph{Inlines: text("Second")}}}},
+		}}},
+		{Break: &document.Break{Kind: document.HorizontalRule}},
+	}
+
+	if got.Metadata.Title != "Notes" {
+		t.Errorf("Metadata.Title = %q, want %q", got.Metadata.Title, "Notes")
+	}
+	if !reflect.DeepEqual(got.Blocks, want) {
+		gotJSON, _ := document.JSONRenderer{}.Render(&document.Document{Blocks: got.Blocks})
+		wantJSON, _ := document.JSONRenderer{}.Render(&document.Document{Blocks: want})
+		t.Errorf("Document().Blocks =\n%s\nwant\n%s", gotJSON, wantJSON)
+	}
+
+	// The markdown renderer writes the same tree as Convert
+	markdown, err := NewConverter(doc).Render(NewRenderer(Options{}))
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	converted, err := NewConverter(doc).Convert()
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	if markdown != converted {
+		t.Errorf("Render() = %q, want %q", markdown, converted)
+	}
+}
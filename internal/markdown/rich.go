@@ -0,0 +1,62 @@
+package markdown
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/famasya/gdocs-cli/internal/document"
+	"google.golang.org/api/docs/v1"
+)
+
+// Colors that carry no meaning on their own: default text, page
+// background and the color Docs gives links.
+const (
+	defaultTextColor       = "#000000"
+	defaultBackgroundColor = "#ffffff"
+	defaultLinkColor       = "#1155cc"
+)
+
+// applyRichStyle wraps text in inline HTML for styles markdown cannot
+// express: <u>, <sup>, <sub>, <mark> and colored spans.
+func applyRichStyle(text string, style document.TextStyle) string {
+	switch style.BaselineOffset {
+	case document.Superscript:
+		text = "<sup>" + text + "</sup>"
+	case document.Subscript:
+		text = "<sub>" + text + "</sub>"
+	}
+
+	if style.Underline {
+		text = "<u>" + text + "</u>"
+	}
+
+	var css []string
+	if style.Color != "" {
+		css = append(css, "color: "+style.Color)
+	}
+	if style.Background != "" && style.Background != document.HighlightColor {
+		css = append(css, "background-color: "+style.Background)
+	}
+	if len(css) > 0 {
+		text = `<span style="` + strings.Join(css, "; ") + `">` + text + "</span>"
+	}
+	if style.Background == document.HighlightColor {
+		text = "<mark>" + text + "</mark>"
+	}
+
+	return text
+}
+
+// hexColor returns a color as a #rrggbb string, or an empty string if the
+// color is unset or transparent.
+func hexColor(color *docs.OptionalColor) string {
+	if color == nil || color.Color == nil || color.Color.RgbColor == nil {
+		return ""
+	}
+	rgb := color.Color.RgbColor
+	channel := func(v float64) int {
+		return int(math.Round(math.Max(0, math.Min(1, v)) * 255))
+	}
+	return fmt.Sprintf("#%02x%02x%02x", channel(rgb.Red), channel(rgb.Green), channel(rgb.Blue))
+}
@@ -0,0 +1,95 @@
+package markdown
+
+import (
+	"testing"
+
+	"google.golang.org/api/docs/v1"
+)
+
+func rgb(r, g, b float64) *docs.OptionalColor {
+	return &docs.OptionalColor{Color: &docs.Color{RgbColor: &docs.RgbColor{Red: r, Green: g, Blue: b}}}
+}
+
+func TestConvertRichInline(t *testing.T) {
+	tests := []struct {
+		name     string
+		elements []*docs.ParagraphElement
+		rich     bool
+		want     string
+	}{
+		{
+			name: "subscript formula",
+			elements: []*docs.ParagraphElement{
+				{TextRun: &docs.TextRun{Content: "H"}},
+				{TextRun: &docs.TextRun{Content: "2", TextStyle: &docs.TextStyle{BaselineOffset: "SUBSCRIPT"}}},
+				{TextRun: &docs.TextRun{Content: "O"}},
+			},
+			rich: true,
+			want: "H<sub>2</sub>O",
+		},
+		{
+			name: "superscript",
+			elements: []*docs.ParagraphElement{
+				{TextRun: &docs.TextRun{Content: "E = mc"}},
+				{TextRun: &docs.TextRun{Content: "2", TextStyle: &docs.TextStyle{BaselineOffset: "SUPERSCRIPT"}}},
+			},
+			rich: true,
+			want: "E = mc<sup>2</sup>",
+		},
+		{
+			name: "highlighted bold TODO",
+			elements: []*docs.ParagraphElement{
+				{TextRun: &docs.TextRun{Content: "TODO", TextStyle: &docs.TextStyle{Bold: true, BackgroundColor: rgb(1, 1, 0)}}},
+				{TextRun: &docs.TextRun{Content: " check numbers"}},
+			},
+			rich: true,
+			want: "**<mark>TODO</mark>** check numbers",
+		},
+		{
+			name: "underline and colors",
+			elements: []*docs.ParagraphElement{
+				{TextRun: &docs.TextRun{Content: "note", TextStyle: &docs.TextStyle{Underline: true, ForegroundColor: rgb(1, 0, 0), BackgroundColor: rgb(0.8, 0.9, 1)}}},
+			},
+			rich: true,
+			want: `<span style="color: #ff0000; background-color: #cce6ff"><u>note</u></span>`,
+		},
+		{
+			name: "link underline and color are defaults",
+			elements: []*docs.ParagraphElement{
+				{TextRun: &docs.TextRun{Content: "docs", TextStyle: &docs.TextStyle{
+					Underline:       true,
+					ForegroundColor: rgb(0x11/255.0, 0x55/255.0, 0xcc/255.0),
+					Link:            &docs.Link{Url: "https://example.com"},
+				}}},
+			},
+			rich: true,
+			want: "[docs](https://example.com)",
+		},
+		{
+			name: "black text is not colored",
+			elements: []*docs.ParagraphElement{
+				{TextRun: &docs.TextRun{Content: "plain", TextStyle: &docs.TextStyle{ForegroundColor: rgb(0, 0, 0)}}},
+			},
+			rich: true,
+			want: "plain",
+		},
+		{
+			name: "disabled by default",
+			elements: []*docs.ParagraphElement{
+				{TextRun: &docs.TextRun{Content: "H"}},
+				{TextRun: &docs.TextRun{Content: "2", TextStyle: &docs.TextStyle{BaselineOffset: "SUBSCRIPT", Underline: true}}},
+				{TextRun: &docs.TextRun{Content: "O"}},
+			},
+			want: "H2O",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Converter{opts: Options{RichInline: tt.rich}}
+			if got := convertElements(t, c, tt.elements); got != tt.want {
+				t.Errorf("convertElements() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
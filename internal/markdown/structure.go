@@ -1,130 +1,387 @@
 package markdown
 
 import (
+	"fmt"
+	"math"
 	"strings"
 
+	"github.com/famasya/gdocs-cli/internal/document"
 	"google.golang.org/api/docs/v1"
 )
 
 // ConvertParagraph converts a Google Docs paragraph to markdown.
+// Without document context, list items are rendered as bullets.
 func ConvertParagraph(paragraph *docs.Paragraph, style *docs.ParagraphStyle) string {
+	return (&Converter{}).convertParagraph(paragraph, style)
+}
+
+// convertParagraph converts a paragraph using the converter's document context.
+func (c *Converter) convertParagraph(paragraph *docs.Paragraph, style *docs.ParagraphStyle) string {
 	if paragraph == nil {
 		return ""
 	}
 
-	// Get the text content
-	text := ConvertParagraphElements(paragraph.Elements)
+	if paragraph.Bullet != nil {
+		list := &listBuilder{listID: paragraph.Bullet.ListId}
+		c.addListItem(list, paragraph, style)
+		return c.renderer().renderList(list.root(), "")
+	}
+
+	block, ok := c.buildParagraph(paragraph, style)
+	if !ok {
+		return ""
+	}
+	return c.renderer().renderBlock(block)
+}
+
+// buildParagraph builds the block for a paragraph that is not a list item.
+// It returns false for paragraphs that are left out, such as a dropped
+// title.
+func (c *Converter) buildParagraph(paragraph *docs.Paragraph, style *docs.ParagraphStyle) (document.Block, bool) {
+	return c.buildParagraphPart(paragraph, paragraph.Elements, style)
+}
+
+// buildParagraphPart builds the block for some of a paragraph's elements,
+// such as the text between breaks. Headings are found by the paragraph
+// they are part of, so each part keeps the heading's anchor and number.
+func (c *Converter) buildParagraphPart(paragraph *docs.Paragraph, elements []*docs.ParagraphElement, style *docs.ParagraphStyle) (document.Block, bool) {
+	inlines := c.paragraphInlines(elements)
 
-	// Remove trailing newlines for cleaner output
-	text = strings.TrimRight(text, "\n")
+	// Handle headings
+	if isHeadingStyle(style) {
+		return c.buildHeading(paragraph, style, inlines)
+	}
+
+	// Regular paragraph
+	return document.Block{Paragraph: &document.Paragraph{Inlines: inlines}}, true
+}
+
+// renderParagraph renders a paragraph as markdown.
+func (r *Renderer) renderParagraph(paragraph *document.Paragraph) string {
+	// Get the text content
+	text := r.blockText(paragraph.Inlines)
 
 	// If paragraph is empty, return blank line
 	if text == "" {
 		return "\n"
 	}
 
-	// Handle headings
-	if style != nil && style.NamedStyleType != "" {
-		switch style.NamedStyleType {
-		case "TITLE":
-			return "# " + text + "\n\n"
-		case "SUBTITLE":
-			return "## " + text + "\n\n"
-		case "HEADING_1":
-			return "# " + text + "\n\n"
-		case "HEADING_2":
-			return "## " + text + "\n\n"
-		case "HEADING_3":
-			return "### " + text + "\n\n"
-		case "HEADING_4":
-			return "#### " + text + "\n\n"
-		case "HEADING_5":
-			return "##### " + text + "\n\n"
-		case "HEADING_6":
-			return "###### " + text + "\n\n"
-		}
+	return hardBreaks(text, "") + "\n\n"
+}
+
+// listLevel holds the numbering state of one nesting level of a list.
+type listLevel struct {
+	started bool
+	count   int64
+}
+
+// addListItem adds a list item paragraph to a list. Numbered lists use the
+// glyph type of the item's nesting level from the document's list
+// definitions and keep a counter per list and level.
+func (c *Converter) addListItem(list *listBuilder, paragraph *docs.Paragraph, style *docs.ParagraphStyle) {
+	// Docs strikes through checked checklist items; the task list marker
+	// carries that state instead
+	elements := paragraph.Elements
+	isTask := isCheckboxGlyph(c.nestingLevel(paragraph.Bullet))
+	checked := isTask && isStruckThrough(elements)
+	if checked {
+		elements = withoutStrikethrough(elements)
 	}
 
-	// Handle lists
-	if paragraph.Bullet != nil {
-		return convertListItem(text, paragraph.Bullet)
+	// Empty items render nothing and don't count
+	inlines := c.paragraphInlines(elements)
+	if len(inlines) == 0 {
+		return
 	}
 
-	// Regular paragraph
-	return text + "\n\n"
+	item := document.ListItem{
+		Blocks: []document.Block{{Paragraph: &document.Paragraph{Inlines: inlines}}},
+	}
+	if isTask {
+		item.Checked = &checked
+	}
+
+	bullet := paragraph.Bullet
+	ordered := c.numberListItem(bullet, &item)
+	list.add(int(bullet.NestingLevel), ordered, item, c.itemIndentStart(bullet, style))
 }
 
-// convertListItem converts a list item to markdown.
-func convertListItem(text string, bullet *docs.Bullet) string {
+// numberListItem sets the number of an item in a numbered list and
+// reports whether the item's nesting level is numbered.
+func (c *Converter) numberListItem(bullet *docs.Bullet, item *document.ListItem) bool {
 	// Get nesting level (0-8)
-	nestingLevel := bullet.NestingLevel
+	nestingLevel := int(bullet.NestingLevel)
 
-	// Create indentation (2 spaces per level)
-	indent := strings.Repeat("  ", int(nestingLevel))
+	if c.listLevels == nil {
+		c.listLevels = make(map[string][]listLevel)
+	}
+	levels := c.listLevels[bullet.ListId]
+	for len(levels) <= nestingLevel {
+		levels = append(levels, listLevel{})
+	}
+	// An item at this level restarts the numbering of all deeper levels
+	for i := nestingLevel + 1; i < len(levels); i++ {
+		levels[i] = listLevel{}
+	}
+	c.listLevels[bullet.ListId] = levels
 
-	// Determine bullet type
-	bulletChar := "- "
-	if bullet.ListId != "" {
-		// Check glyph type if available
-		// For simplicity, we'll use "-" for bullets and "1." for numbered
-		// In a more complete implementation, we'd track list properties
-		// from the document.Lists map
-		bulletChar = "- "
+	level := c.nestingLevel(bullet)
+	if !isOrderedGlyph(level) {
+		return false
 	}
+	state := &levels[nestingLevel]
+	if !state.started {
+		state.started = true
+		state.count = max(level.StartNumber, 1)
+	} else {
+		state.count++
+	}
+	item.Number = state.count
+	return true
+}
 
-	return indent + bulletChar + text + "\n"
+// nestingLevel returns the list definition for the bullet's nesting level,
+// or nil if the document does not define it.
+func (c *Converter) nestingLevel(bullet *docs.Bullet) *docs.NestingLevel {
+	list, ok := c.lists[bullet.ListId]
+	if !ok || list.ListProperties == nil {
+		return nil
+	}
+	levels := list.ListProperties.NestingLevels
+	if int(bullet.NestingLevel) >= len(levels) {
+		return nil
+	}
+	return levels[bullet.NestingLevel]
 }
 
-// ConvertTable converts a Google Docs table to markdown.
-func ConvertTable(table *docs.Table) string {
-	if table == nil || len(table.TableRows) == 0 {
-		return ""
+// listBuilder assembles consecutive list item paragraphs into a tree of
+// lists.
+type listBuilder struct {
+	listID string
+	// levels holds the open list at each nesting level; the last item of
+	// each is the item that deeper items and continuations belong to
+	levels []openList
+	top    *document.List
+}
+
+// openList is a list that following items can be added to.
+type openList struct {
+	list        *document.List
+	indentStart float64 // indent of the last item's text in the document, in points
+}
+
+// root returns the outermost list.
+func (b *listBuilder) root() *document.List {
+	if b.top == nil {
+		b.top = &document.List{}
 	}
+	return b.top
+}
 
+// add adds an item at a nesting level. Levels without a parent item get
+// an item that only holds the nested list.
+func (b *listBuilder) add(level int, ordered bool, item document.ListItem, indentStart float64) {
+	b.levels = b.levels[:min(level+1, len(b.levels))]
+	for len(b.levels) <= level {
+		list := b.root()
+		if depth := len(b.levels); depth > 0 {
+			list = &document.List{}
+			b.appendBlocks(depth-1, document.Block{List: list})
+		}
+		b.levels = append(b.levels, openList{list: list})
+	}
+
+	open := &b.levels[level]
+	if len(open.list.Items) == 0 {
+		open.list.Ordered = ordered
+	}
+	open.list.Items = append(open.list.Items, item)
+	open.indentStart = indentStart
+}
+
+// appendBlocks appends blocks to the last item at a nesting level and
+// closes the lists nested deeper.
+func (b *listBuilder) appendBlocks(level int, blocks ...document.Block) {
+	list := b.levels[level].list
+	if len(list.Items) == 0 {
+		list.Items = append(list.Items, document.ListItem{})
+	}
+	item := &list.Items[len(list.Items)-1]
+	item.Blocks = append(item.Blocks, blocks...)
+	b.levels = b.levels[:level+1]
+}
+
+// continuedLevel returns the nesting level of the list item that a
+// paragraph without a bullet continues: the deepest item whose text is
+// indented as far as the paragraph.
+func (b *listBuilder) continuedLevel(paragraph *docs.Paragraph) (int, bool) {
+	style := paragraph.ParagraphStyle
+	if paragraph.Bullet != nil || isHeadingStyle(style) || style == nil || style.IndentStart == nil {
+		return 0, false
+	}
+	indent := style.IndentStart.Magnitude
+	if indent == 0 {
+		return 0, false
+	}
+	for i := len(b.levels) - 1; i >= 0; i-- {
+		if len(b.levels[i].list.Items) > 0 && math.Abs(b.levels[i].indentStart-indent) < 1 {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// itemIndentStart returns how far a list item's text is indented in the
+// document, from its paragraph style or else its list's nesting level.
+func (c *Converter) itemIndentStart(bullet *docs.Bullet, style *docs.ParagraphStyle) float64 {
+	if style != nil && style.IndentStart != nil {
+		return style.IndentStart.Magnitude
+	}
+	if level := c.nestingLevel(bullet); level != nil && level.IndentStart != nil {
+		return level.IndentStart.Magnitude
+	}
+	return 0
+}
+
+// renderList renders a list with its items indented by indent. Later
+// lines of an item, its nested lists and paragraphs continuing it line up
+// with the item's text.
+func (r *Renderer) renderList(list *document.List, indent string) string {
 	var builder strings.Builder
 
-	// Process each row
-	for i, row := range table.TableRows {
-		// Process each cell
-		builder.WriteString("|")
-		for _, cell := range row.TableCells {
-			cellText := extractTableCellText(cell)
-			builder.WriteString(" ")
-			builder.WriteString(cellText)
-			builder.WriteString(" |")
+	for _, item := range list.Items {
+		marker := "- "
+		if list.Ordered {
+			marker = fmt.Sprintf("%d. ", item.Number)
+		}
+		content := indent + strings.Repeat(" ", len(marker))
+
+		blocks := item.Blocks
+		if len(blocks) > 0 && blocks[0].Paragraph != nil {
+			text := r.blockText(blocks[0].Paragraph.Inlines)
+			if item.Checked != nil {
+				text = r.checkbox(*item.Checked) + text
+			}
+			builder.WriteString(indent + marker + hardBreaks(text, content) + "\n")
+			blocks = blocks[1:]
+		} else {
+			// Levels without a parent marker are indented by 2 spaces
+			content = indent + "  "
 		}
-		builder.WriteString("\n")
 
-		// Add separator after first row (header)
-		if i == 0 {
-			builder.WriteString("|")
-			for range row.TableCells {
-				builder.WriteString("---|")
+		for _, block := range blocks {
+			if block.List != nil {
+				builder.WriteString(r.renderList(block.List, content))
+				continue
 			}
-			builder.WriteString("\n")
+			builder.WriteString(r.renderListContinuation(block, content))
 		}
 	}
 
-	builder.WriteString("\n")
 	return builder.String()
 }
 
-// extractTableCellText extracts plain text from a table cell.
-func extractTableCellText(cell *docs.TableCell) string {
-	if cell == nil || len(cell.Content) == 0 {
+// renderListContinuation renders a block continuing a list item, indented
+// to the item's content.
+func (r *Renderer) renderListContinuation(block document.Block, indent string) string {
+	text := strings.TrimRight(r.renderBlock(block), "\n")
+	if text == "" {
 		return ""
 	}
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = indent + line
+		}
+	}
+	return "\n" + strings.Join(lines, "\n") + "\n"
+}
 
-	var builder strings.Builder
-	for _, element := range cell.Content {
-		if element.Paragraph != nil {
-			text := ConvertParagraphElements(element.Paragraph.Elements)
-			text = strings.TrimSpace(text)
-			// Replace newlines with spaces for single-line cell content
-			text = strings.ReplaceAll(text, "\n", " ")
-			builder.WriteString(text)
+// hardBreaks turns soft line breaks into markdown hard line breaks,
+// indenting the following lines by indent.
+func hardBreaks(text string, indent string) string {
+	return strings.ReplaceAll(text, "\v", "\\\n"+indent)
+}
+
+// checkboxSymbols are glyph symbols that mark checklist items.
+var checkboxSymbols = map[string]bool{"☐": true, "☑": true, "☒": true}
+
+// isCheckboxGlyph reports whether a nesting level belongs to a checklist.
+// Docs returns checklist levels without a glyph type or symbol.
+func isCheckboxGlyph(level *docs.NestingLevel) bool {
+	if level == nil {
+		return false
+	}
+	if level.GlyphSymbol != "" {
+		return checkboxSymbols[level.GlyphSymbol]
+	}
+	return level.GlyphType == "" || level.GlyphType == "GLYPH_TYPE_UNSPECIFIED"
+}
+
+// isStruckThrough reports whether all text in the elements is struck through.
+func isStruckThrough(elements []*docs.ParagraphElement) bool {
+	hasText := false
+	for _, element := range elements {
+		run := element.TextRun
+		if run == nil || strings.TrimSpace(run.Content) == "" {
+			continue
+		}
+		if run.TextStyle == nil || !run.TextStyle.Strikethrough {
+			return false
 		}
+		hasText = true
 	}
+	return hasText
+}
 
-	return builder.String()
+// withoutStrikethrough returns a copy of the elements with strikethrough
+// removed from their text runs.
+func withoutStrikethrough(elements []*docs.ParagraphElement) []*docs.ParagraphElement {
+	result := make([]*docs.ParagraphElement, len(elements))
+	for i, element := range elements {
+		result[i] = element
+		if element.TextRun == nil || element.TextRun.TextStyle == nil {
+			continue
+		}
+		style := *element.TextRun.TextStyle
+		style.Strikethrough = false
+		run := *element.TextRun
+		run.TextStyle = &style
+		el := *element
+		el.TextRun = &run
+		result[i] = &el
+	}
+	return result
+}
+
+// isOrderedGlyph reports whether a nesting level renders numbered items.
+// Letters and roman numerals are rendered as numbers, since markdown only
+// supports numeric ordered lists.
+func isOrderedGlyph(level *docs.NestingLevel) bool {
+	if level == nil || level.GlyphSymbol != "" {
+		return false
+	}
+	switch level.GlyphType {
+	case "", "GLYPH_TYPE_UNSPECIFIED", "NONE":
+		return false
+	}
+	return true
+}
+
+// walkParagraphs calls fn for every paragraph in the content, including
+// paragraphs inside tables.
+func walkParagraphs(content []*docs.StructuralElement, fn func(*docs.Paragraph)) {
+	for _, element := range content {
+		if element.Paragraph != nil {
+			fn(element.Paragraph)
+		} else if element.Table != nil {
+			for _, row := range element.Table.TableRows {
+				for _, cell := range row.TableCells {
+					if cell != nil {
+						walkParagraphs(cell.Content, fn)
+					}
+				}
+			}
+		}
+	}
 }
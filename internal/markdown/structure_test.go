@@ -1,6 +1,7 @@
 package markdown
 
 import (
+	"strings"
 	"testing"
 
 	"google.golang.org/api/docs/v1"
@@ -245,10 +246,321 @@ func TestConvertTable(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := ConvertTable(tt.table)
+			got := convertContent(t, &Converter{}, []*docs.StructuralElement{{Table: tt.table}})
 			if got != tt.want {
-				t.Errorf("ConvertTable() = %q, want %q", got, tt.want)
+				t.Errorf("convertContent() = %q, want %q", got, tt.want)
 			}
 		})
 	}
 }
+
+func TestConvertOrderedLists(t *testing.T) {
+	item := func(listID string, level int64, text string) *docs.StructuralElement {
+		return &docs.StructuralElement{
+			Paragraph: &docs.Paragraph{
+				Elements: []*docs.ParagraphElement{
+					{TextRun: &docs.TextRun{Content: text + "\n"}},
+				},
+				Bullet: &docs.Bullet{ListId: listID, NestingLevel: level},
+			},
+		}
+	}
+	numbered := docs.List{
+		ListProperties: &docs.ListProperties{
+			NestingLevels: []*docs.NestingLevel{
+				{GlyphType: "DECIMAL", GlyphFormat: "%0."},
+				{GlyphType: "ALPHA", GlyphFormat: "%1."},
+				{GlyphSymbol: "●"},
+			},
+		},
+	}
+	startAtFive := docs.List{
+		ListProperties: &docs.ListProperties{
+			NestingLevels: []*docs.NestingLevel{
+				{GlyphType: "DECIMAL", StartNumber: 5},
+			},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		lists   map[string]docs.List
+		content []*docs.StructuralElement
+		want    string
+	}{
+		{
+			name:  "numbered items",
+			lists: map[string]docs.List{"l1": numbered},
+			content: []*docs.StructuralElement{
+				item("l1", 0, "First"),
+				item("l1", 0, "Second"),
+				item("l1", 0, "Third"),
+			},
+			want: "1. First\n2. Second\n3. Third\n",
+		},
+		{
+			name:  "nested counters reset with parent",
+			lists: map[string]docs.List{"l1": numbered},
+			content: []*docs.StructuralElement{
+				item("l1", 0, "Step one"),
+				item("l1", 1, "Sub a"),
+				item("l1", 1, "Sub b"),
+				item("l1", 0, "Step two"),
+				item("l1", 1, "Sub a again"),
+				item("l1", 2, "Bullet"),
+			},
+			want: "1. Step one\n   1. Sub a\n   2. Sub b\n2. Step two\n   1. Sub a again\n      - Bullet\n",
+		},
+		{
+			name:  "start number",
+			lists: map[string]docs.List{"l2": startAtFive},
+			content: []*docs.StructuralElement{
+				item("l2", 0, "Five"),
+				item("l2", 0, "Six"),
+			},
+			want: "5. Five\n6. Six\n",
+		},
+		{
+			name:  "new list restarts numbering",
+			lists: map[string]docs.List{"l1": numbered, "l3": numbered},
+			content: []*docs.StructuralElement{
+				item("l1", 0, "A1"),
+				item("l1", 0, "A2"),
+				{Paragraph: &docs.Paragraph{Elements: []*docs.ParagraphElement{{TextRun: &docs.TextRun{Content: "Between\n"}}}}},
+				item("l3", 0, "B1"),
+			},
+			want: "1. A1\n2. A2\n\nBetween\n\n1. B1\n",
+		},
+		{
+			name:  "adjacent lists are kept apart",
+			lists: map[string]docs.List{"l1": numbered, "l3": numbered},
+			content: []*docs.StructuralElement{
+				item("l1", 0, "A1"),
+				item("l1", 0, "A2"),
+				item("l3", 0, "B1"),
+				item("l3", 0, "B2"),
+			},
+			want: "1. A1\n2. A2\n\n<!-- -->\n\n1. B1\n2. B2\n",
+		},
+		{
+			name:  "wide parent marker indents children",
+			lists: map[string]docs.List{"l2": startAtFive, "l4": numbered},
+			content: []*docs.StructuralElement{
+				item("l2", 0, "Five"),
+				item("l2", 0, "Six"),
+				item("l2", 0, "Seven"),
+				item("l2", 0, "Eight"),
+				item("l2", 0, "Nine"),
+				item("l2", 0, "Ten"),
+				item("l2", 1, "Nested"),
+			},
+			want: "5. Five\n6. Six\n7. Seven\n8. Eight\n9. Nine\n10. Ten\n    - Nested\n",
+		},
+		{
+			name:  "unknown list falls back to bullets",
+			lists: nil,
+			content: []*docs.StructuralElement{
+				item("missing", 0, "Item"),
+			},
+			want: "- Item\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := &docs.Document{
+				Body:  &docs.Body{Content: tt.content},
+				Lists: tt.lists,
+			}
+			got := convertBody(t, NewConverter(doc))
+			if got != tt.want {
+				t.Errorf("convertBody() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvertTwice(t *testing.T) {
+	doc := &docs.Document{
+		Body: &docs.Body{Content: []*docs.StructuralElement{
+			{Paragraph: &docs.Paragraph{
+				Elements: []*docs.ParagraphElement{{TextRun: &docs.TextRun{Content: "First\n"}}},
+				Bullet:   &docs.Bullet{ListId: "l1"},
+			}},
+			{Paragraph: &docs.Paragraph{
+				Elements: []*docs.ParagraphElement{
+					{TextRun: &docs.TextRun{Content: "Second"}},
+					{FootnoteReference: &docs.FootnoteReference{FootnoteId: "kix.a"}},
+					{TextRun: &docs.TextRun{Content: "\n"}},
+				},
+				Bullet: &docs.Bullet{ListId: "l1"},
+			}},
+		}},
+		Lists: map[string]docs.List{"l1": {ListProperties: &docs.ListProperties{
+			NestingLevels: []*docs.NestingLevel{{GlyphType: "DECIMAL"}},
+		}}},
+		Footnotes: map[string]docs.Footnote{
+			"kix.a": {FootnoteId: "kix.a", Content: []*docs.StructuralElement{footnoteParagraph("Note.\n")}},
+		},
+	}
+
+	c := NewConverter(doc)
+	first, err := c.Convert()
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	second, err := c.Convert()
+	if err != nil {
+		t.Fatalf("second Convert() error = %v", err)
+	}
+
+	want := "1. First\n2. Second[^1]\n\n[^1]: Note.\n"
+	if !strings.HasSuffix(first, want) {
+		t.Errorf("Convert() = %q, want suffix %q", first, want)
+	}
+	if second != first {
+		t.Errorf("second Convert() = %q, want %q", second, first)
+	}
+}
+
+func TestConvertChecklists(t *testing.T) {
+	item := func(level int64, text string, done bool) *docs.StructuralElement {
+		return &docs.StructuralElement{
+			Paragraph: &docs.Paragraph{
+				Elements: []*docs.ParagraphElement{
+					{TextRun: &docs.TextRun{Content: text, TextStyle: &docs.TextStyle{Strikethrough: done}}},
+					{TextRun: &docs.TextRun{Content: "\n"}},
+				},
+				Bullet: &docs.Bullet{ListId: "check", NestingLevel: level},
+			},
+		}
+	}
+	checklist := docs.List{
+		ListProperties: &docs.ListProperties{
+			NestingLevels: []*docs.NestingLevel{
+				{GlyphType: "GLYPH_TYPE_UNSPECIFIED"},
+				{GlyphType: "GLYPH_TYPE_UNSPECIFIED"},
+			},
+		},
+	}
+
+	doc := &docs.Document{
+		Body: &docs.Body{Content: []*docs.StructuralElement{
+			item(0, "Write spec", true),
+			item(0, "Review spec", false),
+			item(1, "Security review", true),
+			item(1, "Legal review", false),
+		}},
+		Lists: map[string]docs.List{"check": checklist},
+	}
+
+	want := "- [x] Write spec\n- [ ] Review spec\n  - [x] Security review\n  - [ ] Legal review\n"
+	if got := convertBody(t, NewConverter(doc)); got != want {
+		t.Errorf("convertBody() = %q, want %q", got, want)
+	}
+}
+
+func TestConvertSoftLineBreaks(t *testing.T) {
+	tests := []struct {
+		name    string
+		content []*docs.StructuralElement
+		want    string
+	}{
+		{
+			name: "paragraph",
+			content: []*docs.StructuralElement{
+				{Paragraph: &docs.Paragraph{Elements: []*docs.ParagraphElement{
+					{TextRun: &docs.TextRun{Content: "Line one\v# not a heading\v\n"}},
+				}}},
+			},
+			want: "Line one\\\n\\# not a heading\n\n",
+		},
+		{
+			name: "styled run",
+			content: []*docs.StructuralElement{
+				{Paragraph: &docs.Paragraph{Elements: []*docs.ParagraphElement{
+					{TextRun: &docs.TextRun{Content: "bold\vtext", TextStyle: &docs.TextStyle{Bold: true}}},
+					{TextRun: &docs.TextRun{Content: "\n"}},
+				}}},
+			},
+			want: "**bold**\\\n**text**\n\n",
+		},
+		{
+			name: "heading",
+			content: []*docs.StructuralElement{
+				{Paragraph: &docs.Paragraph{
+					Elements:       []*docs.ParagraphElement{{TextRun: &docs.TextRun{Content: "Part one\vIntro\n"}}},
+					ParagraphStyle: &docs.ParagraphStyle{NamedStyleType: "HEADING_1"},
+				}},
+			},
+			want: "# Part one Intro\n\n",
+		},
+		{
+			name: "list item",
+			content: []*docs.StructuralElement{
+				{Paragraph: &docs.Paragraph{
+					Elements: []*docs.ParagraphElement{{TextRun: &docs.TextRun{Content: "Item\vmore\n"}}},
+					Bullet:   &docs.Bullet{ListId: "l1"},
+				}},
+			},
+			want: "- Item\\\n  more\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := &docs.Document{Body: &docs.Body{Content: tt.content}}
+			got := convertBody(t, NewConverter(doc))
+			if got != tt.want {
+				t.Errorf("convertBody() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvertListContinuations(t *testing.T) {
+	indent := func(pt float64) *docs.ParagraphStyle {
+		return &docs.ParagraphStyle{IndentStart: &docs.Dimension{Magnitude: pt, Unit: "PT"}}
+	}
+	item := func(level int64, text string) *docs.StructuralElement {
+		return &docs.StructuralElement{Paragraph: &docs.Paragraph{
+			Elements:       []*docs.ParagraphElement{{TextRun: &docs.TextRun{Content: text + "\n"}}},
+			Bullet:         &docs.Bullet{ListId: "l1", NestingLevel: level},
+			ParagraphStyle: indent(36 * float64(level+1)),
+		}}
+	}
+	paragraph := func(pt float64, text string) *docs.StructuralElement {
+		return &docs.StructuralElement{Paragraph: &docs.Paragraph{
+			Elements:       []*docs.ParagraphElement{{TextRun: &docs.TextRun{Content: text + "\n"}}},
+			ParagraphStyle: indent(pt),
+		}}
+	}
+	numbered := docs.List{ListProperties: &docs.ListProperties{NestingLevels: []*docs.NestingLevel{
+		{GlyphType: "DECIMAL"},
+		{GlyphSymbol: "●"},
+	}}}
+
+	doc := &docs.Document{
+		Body: &docs.Body{Content: []*docs.StructuralElement{
+			item(0, "Install"),
+			paragraph(36, "Run the installer."),
+			item(1, "Linux"),
+			paragraph(72, "Use the package."),
+			paragraph(36, "Then restart."),
+			item(0, "Configure"),
+			paragraph(0, "Done"),
+		}},
+		Lists: map[string]docs.List{"l1": numbered},
+	}
+
+	want := "1. Install\n" +
+		"\n   Run the installer.\n" +
+		"   - Linux\n" +
+		"\n     Use the package.\n" +
+		"\n   Then restart.\n" +
+		"2. Configure\n" +
+		"\nDone\n\n"
+	if got := convertBody(t, NewConverter(doc)); got != want {
+		t.Errorf("convertBody() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,50 @@
+package markdown
+
+import (
+	"strings"
+
+	"github.com/famasya/gdocs-cli/internal/document"
+)
+
+// markSuggestions wraps converted run text in CriticMarkup if the run is a
+// suggested insertion or deletion. Trailing newlines stay outside the
+// markup so it does not span paragraphs.
+func (r *Renderer) markSuggestions(text string, style document.TextStyle) string {
+	if len(style.SuggestedInsertionIDs) == 0 && len(style.SuggestedDeletionIDs) == 0 {
+		return text
+	}
+
+	trimmed := strings.TrimRight(text, "\n")
+	if strings.TrimSpace(trimmed) == "" {
+		return text
+	}
+	suffix := text[len(trimmed):]
+	text = trimmed
+
+	if len(style.SuggestedInsertionIDs) > 0 {
+		text = "{++" + text + "++}" + r.suggestionAuthor(style.SuggestedInsertionIDs)
+	}
+	if len(style.SuggestedDeletionIDs) > 0 {
+		text = "{--" + text + "--}" + r.suggestionAuthor(style.SuggestedDeletionIDs)
+	}
+
+	return text + suffix
+}
+
+// suggestionAuthor returns a CriticMarkup comment naming the authors of the
+// suggestions, or an empty string if none are known.
+func (r *Renderer) suggestionAuthor(ids []string) string {
+	var authors []string
+	seen := make(map[string]bool)
+	for _, id := range ids {
+		author := r.opts.SuggestionAuthors[id]
+		if author != "" && !seen[author] {
+			seen[author] = true
+			authors = append(authors, author)
+		}
+	}
+	if len(authors) == 0 {
+		return ""
+	}
+	return "{>>" + strings.Join(authors, ", ") + "<<}"
+}
@@ -0,0 +1,48 @@
+package markdown
+
+import (
+	"testing"
+
+	"google.golang.org/api/docs/v1"
+)
+
+func TestConvertSuggestions(t *testing.T) {
+	elements := []*docs.ParagraphElement{
+		{TextRun: &docs.TextRun{Content: "The limit is "}},
+		{TextRun: &docs.TextRun{Content: "10", SuggestedDeletionIds: []string{"suggest.a"}}},
+		{TextRun: &docs.TextRun{Content: "2", SuggestedInsertionIds: []string{"suggest.b"}}},
+		{TextRun: &docs.TextRun{Content: "0", SuggestedInsertionIds: []string{"suggest.b"}, TextStyle: &docs.TextStyle{FontSize: &docs.Dimension{Magnitude: 11}}}},
+		{TextRun: &docs.TextRun{Content: " requests.\n"}},
+		{TextRun: &docs.TextRun{Content: "bold\n", TextStyle: &docs.TextStyle{Bold: true}, SuggestedInsertionIds: []string{"suggest.c"}}},
+	}
+
+	tests := []struct {
+		name string
+		opts Options
+		want string
+	}{
+		{
+			name: "critic markup",
+			opts: Options{CriticMarkup: true},
+			want: "The limit is {--10--}{++20++} requests.\n{++**bold**++}",
+		},
+		{
+			name: "with authors",
+			opts: Options{CriticMarkup: true, SuggestionAuthors: map[string]string{"suggest.a": "Alice", "suggest.b": "Bob"}},
+			want: "The limit is {--10--}{>>Alice<<}{++20++}{>>Bob<<} requests.\n{++**bold**++}",
+		},
+		{
+			name: "disabled",
+			want: "The limit is 1020 requests.\n**bold**",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Converter{opts: tt.opts}
+			if got := convertElements(t, c, elements); got != tt.want {
+				t.Errorf("convertElements() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,196 @@
+package markdown
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/famasya/gdocs-cli/internal/document"
+	"google.golang.org/api/docs/v1"
+)
+
+// buildTable builds a table, leaving out the cells hidden by merged cells.
+func (c *Converter) buildTable(table *docs.Table) *document.Table {
+	if table == nil || len(table.TableRows) == 0 {
+		return nil
+	}
+
+	t := &document.Table{}
+
+	// covered marks grid positions hidden by a merged cell
+	covered := make(map[[2]int]bool)
+	for r, row := range table.TableRows {
+		var cells []document.TableCell
+		for col, cell := range row.TableCells {
+			if covered[[2]int{r, col}] || cell == nil {
+				continue
+			}
+			tc := document.TableCell{Blocks: c.buildContent(cell.Content)}
+			if style := cell.TableCellStyle; style != nil {
+				rowSpan := max(style.RowSpan, 1)
+				colSpan := max(style.ColumnSpan, 1)
+				if rowSpan > 1 {
+					tc.RowSpan = int(rowSpan)
+				}
+				if colSpan > 1 {
+					tc.ColSpan = int(colSpan)
+				}
+				for i := 0; i < int(rowSpan); i++ {
+					for j := 0; j < int(colSpan); j++ {
+						if i != 0 || j != 0 {
+							covered[[2]int{r + i, col + j}] = true
+						}
+					}
+				}
+			}
+			cells = append(cells, tc)
+		}
+		t.Rows = append(t.Rows, document.TableRow{Cells: cells})
+	}
+
+	return t
+}
+
+// renderTable renders a table as a pipe table. Tables that a pipe table
+// cannot represent are rendered as HTML. Pandoc gets grid tables and
+// CommonMark, which has no tables, always gets HTML.
+func (r *Renderer) renderTable(table *document.Table) string {
+	switch {
+	case r.opts.Flavor == FlavorPandoc:
+		return document.GridTable(table, func(cell document.TableCell) string {
+			return strings.TrimSpace(r.renderBlocks(cell.Blocks))
+		}) + "\n"
+	case r.opts.Flavor == FlavorCommonMark || r.needsHTMLTable(table):
+		return r.renderHTMLTable(table)
+	}
+
+	var builder strings.Builder
+
+	// Process each row
+	for i, row := range table.Rows {
+		// Process each cell
+		builder.WriteString("|")
+		for _, cell := range row.Cells {
+			builder.WriteString(" ")
+			builder.WriteString(r.cellText(cell))
+			builder.WriteString(" |")
+		}
+		builder.WriteString("\n")
+
+		// Add separator after first row (header)
+		if i == 0 {
+			builder.WriteString("|")
+			for range row.Cells {
+				builder.WriteString("---|")
+			}
+			builder.WriteString("\n")
+		}
+	}
+
+	builder.WriteString("\n")
+	return builder.String()
+}
+
+// cellText renders the content of a cell on a single line.
+func (r *Renderer) cellText(cell document.TableCell) string {
+	var builder strings.Builder
+	for _, block := range cell.Blocks {
+		text := strings.TrimSpace(r.cellLine(block))
+		// Replace newlines with spaces for single-line cell content
+		text = strings.ReplaceAll(text, "\n", " ")
+		text = strings.ReplaceAll(text, "\v", "<br>")
+		// Escape pipes so they don't split the cell
+		text = strings.ReplaceAll(text, "|", "\\|")
+		builder.WriteString(text)
+	}
+	return builder.String()
+}
+
+// cellLine renders a block of a pipe table cell: the text of paragraphs
+// and headings, and single lines of code as code spans.
+func (r *Renderer) cellLine(block document.Block) string {
+	switch {
+	case block.Paragraph != nil:
+		return r.renderInlines(block.Paragraph.Inlines)
+	case block.Heading != nil:
+		return r.renderInlines(block.Heading.Inlines)
+	case block.CodeBlock != nil:
+		return formatCode(block.CodeBlock.Code)
+	}
+	return ""
+}
+
+// needsHTMLTable reports whether a table has content that a markdown pipe
+// table cannot represent: merged cells, multiple paragraphs, lists or
+// nested tables inside a cell.
+func (r *Renderer) needsHTMLTable(table *document.Table) bool {
+	for _, row := range table.Rows {
+		for _, cell := range row.Cells {
+			if cell.RowSpan > 1 || cell.ColSpan > 1 {
+				return true
+			}
+			paragraphs := 0
+			for _, block := range cell.Blocks {
+				if block.List != nil || block.Table != nil || block.Blockquote != nil {
+					return true
+				}
+				if block.CodeBlock != nil && strings.Contains(block.CodeBlock.Code, "\n") {
+					return true
+				}
+				if strings.TrimSpace(r.cellLine(block)) != "" {
+					paragraphs++
+				}
+			}
+			if paragraphs > 1 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// renderHTMLTable renders a table as an HTML table with row and column
+// spans. Cell content is rendered as markdown between blank lines so
+// markdown renderers still format it.
+func (r *Renderer) renderHTMLTable(table *document.Table) string {
+	var builder strings.Builder
+	builder.WriteString("<table>\n")
+
+	for i, row := range table.Rows {
+		builder.WriteString("<tr>\n")
+		tag := "td"
+		if i == 0 {
+			tag = "th"
+		}
+		for _, cell := range row.Cells {
+			var attrs string
+			if cell.RowSpan > 1 {
+				attrs += fmt.Sprintf(" rowspan=\"%d\"", cell.RowSpan)
+			}
+			if cell.ColSpan > 1 {
+				attrs += fmt.Sprintf(" colspan=\"%d\"", cell.ColSpan)
+			}
+
+			// Keep paragraphs, lists and nested tables
+			content := strings.TrimSpace(r.renderBlocks(cell.Blocks))
+			switch {
+			case content == "":
+				builder.WriteString(fmt.Sprintf("<%s%s></%s>\n", tag, attrs, tag))
+			case isPlainCellText(content):
+				builder.WriteString(fmt.Sprintf("<%s%s>%s</%s>\n", tag, attrs, html.EscapeString(content), tag))
+			default:
+				builder.WriteString(fmt.Sprintf("<%s%s>\n\n%s\n\n</%s>\n", tag, attrs, content, tag))
+			}
+		}
+		builder.WriteString("</tr>\n")
+	}
+
+	builder.WriteString("</table>\n\n")
+	return builder.String()
+}
+
+// isPlainCellText reports whether cell content is a single line without
+// markdown syntax, so it can be written inline inside an HTML cell.
+func isPlainCellText(content string) bool {
+	return !strings.ContainsAny(content, "\n*_`[]~<>\\!#|")
+}
@@ -0,0 +1,120 @@
+package markdown
+
+import (
+	"testing"
+
+	"google.golang.org/api/docs/v1"
+)
+
+// textCell creates a table cell with one paragraph per text.
+func textCell(texts ...string) *docs.TableCell {
+	cell := &docs.TableCell{}
+	for _, text := range texts {
+		cell.Content = append(cell.Content, &docs.StructuralElement{
+			Paragraph: &docs.Paragraph{
+				Elements: []*docs.ParagraphElement{{TextRun: &docs.TextRun{Content: text + "\n"}}},
+			},
+		})
+	}
+	return cell
+}
+
+// spanCell creates a single-paragraph table cell spanning rows and columns.
+func spanCell(text string, rowSpan, colSpan int64) *docs.TableCell {
+	cell := textCell(text)
+	cell.TableCellStyle = &docs.TableCellStyle{RowSpan: rowSpan, ColumnSpan: colSpan}
+	return cell
+}
+
+func tableOf(rows ...[]*docs.TableCell) *docs.Table {
+	table := &docs.Table{}
+	for _, cells := range rows {
+		table.TableRows = append(table.TableRows, &docs.TableRow{TableCells: cells})
+	}
+	return table
+}
+
+func TestConvertTableFallback(t *testing.T) {
+	tests := []struct {
+		name  string
+		table *docs.Table
+		want  string
+	}{
+		{
+			name: "pipes are escaped in pipe tables",
+			table: tableOf(
+				[]*docs.TableCell{textCell("Flag"), textCell("Values")},
+				[]*docs.TableCell{textCell("--mode"), textCell("a|b")},
+			),
+			want: "| Flag | Values |\n|---|---|\n| --mode | a\\|b |\n\n",
+		},
+		{
+			name: "merged cells",
+			table: tableOf(
+				[]*docs.TableCell{spanCell("Name", 1, 2), textCell("")},
+				[]*docs.TableCell{spanCell("Tall", 2, 1), textCell("B1")},
+				[]*docs.TableCell{textCell(""), textCell("B2")},
+			),
+			want: "<table>\n" +
+				"<tr>\n<th colspan=\"2\">Name</th>\n</tr>\n" +
+				"<tr>\n<td rowspan=\"2\">Tall</td>\n<td>B1</td>\n</tr>\n" +
+				"<tr>\n<td>B2</td>\n</tr>\n" +
+				"</table>\n\n",
+		},
+		{
+			name: "multiple paragraphs in a cell",
+			table: tableOf(
+				[]*docs.TableCell{textCell("Step"), textCell("Notes")},
+				[]*docs.TableCell{textCell("1"), textCell("First line", "Second line")},
+			),
+			want: "<table>\n" +
+				"<tr>\n<th>Step</th>\n<th>Notes</th>\n</tr>\n" +
+				"<tr>\n<td>1</td>\n<td>\n\nFirst line\n\nSecond line\n\n</td>\n</tr>\n" +
+				"</table>\n\n",
+		},
+		{
+			name: "list and markup in a cell",
+			table: tableOf(
+				[]*docs.TableCell{textCell("A & B")},
+				[]*docs.TableCell{{
+					Content: []*docs.StructuralElement{
+						{Paragraph: &docs.Paragraph{
+							Elements: []*docs.ParagraphElement{{TextRun: &docs.TextRun{Content: "one\n"}}},
+							Bullet:   &docs.Bullet{ListId: "l"},
+						}},
+						{Paragraph: &docs.Paragraph{
+							Elements: []*docs.ParagraphElement{{TextRun: &docs.TextRun{Content: "two\n"}}},
+							Bullet:   &docs.Bullet{ListId: "l"},
+						}},
+					},
+				}},
+			),
+			want: "<table>\n" +
+				"<tr>\n<th>A &amp; B</th>\n</tr>\n" +
+				"<tr>\n<td>\n\n- one\n- two\n\n</td>\n</tr>\n" +
+				"</table>\n\n",
+		},
+		{
+			name: "nested table",
+			table: tableOf(
+				[]*docs.TableCell{{
+					Content: []*docs.StructuralElement{
+						{Table: tableOf([]*docs.TableCell{textCell("inner")})},
+					},
+				}},
+			),
+			want: "<table>\n" +
+				"<tr>\n<th>\n\n| inner |\n|---|\n\n</th>\n</tr>\n" +
+				"</table>\n\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := convertContent(t, &Converter{}, []*docs.StructuralElement{{Table: tt.table}})
+			if got != tt.want {
+				t.Errorf("convertContent() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,30 +3,138 @@ package markdown
 import (
 	"strings"
 
+	"github.com/famasya/gdocs-cli/internal/document"
 	"google.golang.org/api/docs/v1"
 )
 
 // ConvertTextRun converts a Google Docs TextRun to markdown with formatting.
 func ConvertTextRun(textRun *docs.TextRun) string {
+	c := &Converter{}
+	return c.renderer().renderInlines(c.textInlines(textRun))
+}
+
+// textInlines builds the inlines for a text run, splitting it at soft line
+// breaks so markers and code spans don't span the break.
+func (c *Converter) textInlines(textRun *docs.TextRun) []document.Inline {
 	if textRun == nil || textRun.Content == "" {
-		return ""
+		return nil
+	}
+
+	style := c.textStyle(textRun.TextStyle)
+	style.SuggestedInsertionIDs = textRun.SuggestedInsertionIds
+	style.SuggestedDeletionIDs = textRun.SuggestedDeletionIds
+
+	var inlines []document.Inline
+	for i, line := range strings.Split(textRun.Content, "\v") {
+		if i > 0 {
+			inlines = append(inlines, document.Inline{LineBreak: true})
+		}
+		if line != "" {
+			inlines = append(inlines, document.Inline{Text: &document.Text{Value: line, TextStyle: style}})
+		}
+	}
+	return inlines
+}
+
+// textStyle converts a Docs text style, resolving links and dropping
+// colors that carry no meaning on their own.
+func (c *Converter) textStyle(style *docs.TextStyle) document.TextStyle {
+	if style == nil {
+		return document.TextStyle{}
 	}
 
-	text := textRun.Content
-	style := textRun.TextStyle
+	isLink := style.Link != nil
+	s := document.TextStyle{
+		Bold:          style.Bold,
+		Italic:        style.Italic,
+		Strikethrough: style.Strikethrough,
+		Code:          isMonospace(style),
+		Link:          c.linkURL(style.Link),
+		// Docs underlines links by default
+		Underline: style.Underline && !isLink,
+	}
+
+	switch style.BaselineOffset {
+	case "SUPERSCRIPT":
+		s.BaselineOffset = document.Superscript
+	case "SUBSCRIPT":
+		s.BaselineOffset = document.Subscript
+	}
 
-	return ApplyTextStyle(text, style)
+	if color := hexColor(style.ForegroundColor); color != defaultTextColor && !(isLink && color == defaultLinkColor) {
+		s.Color = color
+	}
+	if background := hexColor(style.BackgroundColor); background != defaultBackgroundColor {
+		s.Background = background
+	}
+
+	return s
+}
+
+// renderText renders a text run, escaping markdown syntax in its content
+// unless raw text output is enabled.
+func (r *Renderer) renderText(t *document.Text) string {
+	text := t.Value
+
+	// Code spans are literal, so only escape regular text
+	if !r.opts.RawText && !t.Code {
+		text = escapeText(text)
+	}
+
+	text = r.applyStyle(text, t.TextStyle)
+
+	if r.opts.CriticMarkup {
+		text = r.markSuggestions(text, t.TextStyle)
+	}
+
+	return text
 }
 
 // ApplyTextStyle applies markdown formatting to text based on TextStyle.
+// Leading and trailing whitespace is kept outside the formatting markers,
+// since markdown does not recognize emphasis that starts or ends with it.
 func ApplyTextStyle(text string, style *docs.TextStyle) string {
 	if style == nil {
 		return text
 	}
+	c := &Converter{}
+	return c.renderer().applyStyle(text, c.textStyle(style))
+}
+
+// applyStyle applies formatting using the renderer's options.
+func (r *Renderer) applyStyle(text string, style document.TextStyle) string {
+	core := strings.TrimSpace(text)
+	if core == "" {
+		return text
+	}
+	start := strings.Index(text, core)
+	leading, trailing := text[:start], text[start+len(core):]
+	text = core
+
+	// Handle monospace text as inline code
+	if style.Code {
+		text = formatCode(text)
+	}
+
+	// Obsidian has its own syntax for highlighted text
+	highlight := r.opts.Flavor == FlavorObsidian && style.Background == document.HighlightColor
+	if highlight {
+		style.Background = ""
+	}
+
+	// Handle underline, baseline offset and colors as inline HTML
+	if r.opts.RichInline {
+		text = applyRichStyle(text, style)
+	}
+	if highlight {
+		text = "==" + text + "=="
+	}
 
 	// Handle links
-	if style.Link != nil && style.Link.Url != "" {
-		text = formatLink(text, style.Link.Url)
+	if style.Link != "" {
+		text = r.link(text, style.Link)
+		// Links never end a line
+		trailing = strings.TrimRight(trailing, "\n")
 	}
 
 	// Handle bold and italic
@@ -39,12 +147,50 @@ func ApplyTextStyle(text string, style *docs.TextStyle) string {
 		text = "*" + text + "*"
 	}
 
-	// Handle strikethrough
-	if style.Strikethrough {
+	// Handle strikethrough, which CommonMark lacks
+	if style.Strikethrough && r.opts.Flavor == FlavorCommonMark {
+		text = "<del>" + text + "</del>"
+	} else if style.Strikethrough {
 		text = "~~" + text + "~~"
 	}
 
-	return text
+	return leading + text + trailing
+}
+
+// inlineStyle is the part of a text style that affects markdown output.
+type inlineStyle struct {
+	bold          bool
+	italic        bool
+	strikethrough bool
+	code          bool
+	link          string
+
+	// Only set for Obsidian
+	highlight bool
+	// Only set in rich inline mode
+	rich string
+	// Only set when rendering suggestions as CriticMarkup
+	suggestions string
+}
+
+// effectiveStyle returns everything about a text style that affects the
+// output.
+func (r *Renderer) effectiveStyle(style document.TextStyle) inlineStyle {
+	s := inlineStyle{
+		bold:          style.Bold,
+		italic:        style.Italic,
+		strikethrough: style.Strikethrough,
+		code:          style.Code,
+		link:          style.Link,
+		highlight:     r.opts.Flavor == FlavorObsidian && style.Background == document.HighlightColor,
+	}
+	if r.opts.RichInline {
+		s.rich = applyRichStyle("", style)
+	}
+	if r.opts.CriticMarkup {
+		s.suggestions = strings.Join(style.SuggestedInsertionIDs, ",") + "|" + strings.Join(style.SuggestedDeletionIDs, ",")
+	}
+	return s
 }
 
 // formatLink creates a markdown link from text and URL.
@@ -54,16 +200,134 @@ func formatLink(text string, url string) string {
 	return "[" + text + "](" + url + ")"
 }
 
-// ConvertParagraphElements converts all paragraph elements to markdown text.
-func ConvertParagraphElements(elements []*docs.ParagraphElement) string {
+// buildInlines builds the inlines for paragraph elements.
+func (c *Converter) buildInlines(elements []*docs.ParagraphElement) []document.Inline {
+	var inlines []document.Inline
+
+	for i := 0; i < len(elements); i++ {
+		element := elements[i]
+		switch {
+		case element.TextRun != nil:
+			inlines = append(inlines, c.textInlines(element.TextRun)...)
+		case element.InlineObjectElement != nil:
+			if image := c.inlineImage(element.InlineObjectElement); image != nil {
+				inlines = append(inlines, document.Inline{Image: image})
+			}
+		case element.FootnoteReference != nil:
+			if ref := c.footnoteRef(element.FootnoteReference); ref != nil {
+				inlines = append(inlines, document.Inline{FootnoteRef: ref})
+			}
+		case element.Person != nil:
+			if mention := personMention(element.Person); mention != nil {
+				inlines = append(inlines, document.Inline{Mention: mention})
+			}
+		case element.RichLink != nil:
+			if text := c.richLinkText(element.RichLink); text != nil {
+				inlines = append(inlines, document.Inline{Text: text})
+			}
+		case element.DateElement != nil:
+			if value := convertDate(element.DateElement); value != "" {
+				inlines = append(inlines, document.Inline{Date: &document.Date{Value: value}})
+			}
+		case element.Equation != nil:
+			math, n := buildEquation(elements, i)
+			inlines = append(inlines, document.Inline{Math: &math})
+			i += n
+		}
+		// Page breaks and horizontal rules split the paragraph before
+		// its elements get here; see buildParagraphBlocks
+	}
+
+	return inlines
+}
+
+// paragraphInlines builds the inlines of a paragraph without the newline
+// that ends it.
+func (c *Converter) paragraphInlines(elements []*docs.ParagraphElement) []document.Inline {
+	return trimInlines(c.buildInlines(elements))
+}
+
+// trimInlines removes trailing newlines and line breaks.
+func trimInlines(inlines []document.Inline) []document.Inline {
+	for len(inlines) > 0 {
+		last := inlines[len(inlines)-1]
+		if last.LineBreak {
+			inlines = inlines[:len(inlines)-1]
+			continue
+		}
+		if last.Text == nil || !strings.HasSuffix(last.Text.Value, "\n") {
+			break
+		}
+		text := *last.Text
+		text.Value = strings.TrimRight(text.Value, "\n")
+		inlines = inlines[:len(inlines)-1]
+		if text.Value != "" {
+			return append(inlines, document.Inline{Text: &text})
+		}
+	}
+	return inlines
+}
+
+// renderInlines renders inlines as markdown. Line breaks are returned as
+// \v for the caller to render for its context.
+func (r *Renderer) renderInlines(inlines []document.Inline) string {
 	var builder strings.Builder
 
-	for _, element := range elements {
-		if element.TextRun != nil {
-			builder.WriteString(ConvertTextRun(element.TextRun))
+	// Docs splits text into many runs; merge adjacent runs that render
+	// the same so they get one set of markers instead of **a****b**
+	var pending *document.Text
+	flush := func() {
+		if pending != nil {
+			builder.WriteString(r.renderText(pending))
+			pending = nil
 		}
-		// Handle other element types if needed (e.g., InlineObject, PageBreak)
 	}
 
+	for _, inline := range inlines {
+		if inline.Text != nil {
+			if pending != nil && r.effectiveStyle(pending.TextStyle) == r.effectiveStyle(inline.Text.TextStyle) {
+				merged := *pending
+				merged.Value += inline.Text.Value
+				pending = &merged
+			} else {
+				flush()
+				pending = inline.Text
+			}
+			continue
+		}
+
+		flush()
+		switch {
+		case inline.LineBreak:
+			builder.WriteString("\v")
+		case inline.Image != nil:
+			builder.WriteString(renderImage(inline.Image))
+		case inline.FootnoteRef != nil:
+			builder.WriteString(r.footnoteRef(inline.FootnoteRef.Number))
+		case inline.Mention != nil:
+			builder.WriteString(r.renderMention(inline.Mention))
+		case inline.Date != nil:
+			builder.WriteString(inline.Date.Value)
+		case inline.Math != nil:
+			builder.WriteString(renderMath(inline.Math))
+		}
+	}
+	flush()
+
 	return builder.String()
 }
+
+// blockText renders the inlines of a paragraph or heading, escaping
+// markdown syntax at the start of each line unless raw text output is
+// enabled.
+func (r *Renderer) blockText(inlines []document.Inline) string {
+	text := strings.TrimRight(r.renderInlines(inlines), "\n\v")
+	if text == "" || r.opts.RawText {
+		return text
+	}
+	lines := strings.Split(text, "\v")
+	for i, line := range lines {
+		lines[i] = escapeLineStart(line)
+	}
+	return strings.Join(lines, "\v")
+}
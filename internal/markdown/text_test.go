@@ -67,6 +67,24 @@ func TestApplyTextStyle(t *testing.T) {
 			style: &docs.TextStyle{Link: &docs.Link{Url: "https://example.com"}},
 			want:  "[click here](https://example.com)",
 		},
+		{
+			name:  "bold with surrounding spaces",
+			text:  " bold ",
+			style: &docs.TextStyle{Bold: true},
+			want:  " **bold** ",
+		},
+		{
+			name:  "italic with trailing newline",
+			text:  "italic\n",
+			style: &docs.TextStyle{Italic: true},
+			want:  "*italic*\n",
+		},
+		{
+			name:  "whitespace only",
+			text:  "  ",
+			style: &docs.TextStyle{Bold: true, Strikethrough: true},
+			want:  "  ",
+		},
 	}
 
 	for _, tt := range tests {
@@ -194,13 +212,47 @@ func TestConvertParagraphElements(t *testing.T) {
 			},
 			want: "This is **bold** and *italic*",
 		},
+		{
+			name: "adjacent runs with the same style are merged",
+			elements: []*docs.ParagraphElement{
+				{TextRun: &docs.TextRun{Content: "foo", TextStyle: &docs.TextStyle{Bold: true}}},
+				{TextRun: &docs.TextRun{Content: "bar", TextStyle: &docs.TextStyle{Bold: true, FontSize: &docs.Dimension{Magnitude: 12}}}},
+				{TextRun: &docs.TextRun{Content: " baz", TextStyle: &docs.TextStyle{}}},
+			},
+			want: "**foobar** baz",
+		},
+		{
+			name: "spaces inside markers move outside",
+			elements: []*docs.ParagraphElement{
+				{TextRun: &docs.TextRun{Content: "Make this", TextStyle: &docs.TextStyle{}}},
+				{TextRun: &docs.TextRun{Content: " bold ", TextStyle: &docs.TextStyle{Bold: true}}},
+				{TextRun: &docs.TextRun{Content: "now.", TextStyle: &docs.TextStyle{}}},
+			},
+			want: "Make this **bold** now.",
+		},
+		{
+			name: "links with different URLs stay separate",
+			elements: []*docs.ParagraphElement{
+				{TextRun: &docs.TextRun{Content: "one", TextStyle: &docs.TextStyle{Link: &docs.Link{Url: "https://a.example"}}}},
+				{TextRun: &docs.TextRun{Content: "two", TextStyle: &docs.TextStyle{Link: &docs.Link{Url: "https://b.example"}}}},
+			},
+			want: "[one](https://a.example)[two](https://b.example)",
+		},
+		{
+			name: "styled run ending the paragraph",
+			elements: []*docs.ParagraphElement{
+				{TextRun: &docs.TextRun{Content: "End ", TextStyle: &docs.TextStyle{}}},
+				{TextRun: &docs.TextRun{Content: "here\n", TextStyle: &docs.TextStyle{Italic: true}}},
+			},
+			want: "End *here*",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := ConvertParagraphElements(tt.elements)
+			got := convertElements(t, &Converter{}, tt.elements)
 			if got != tt.want {
-				t.Errorf("ConvertParagraphElements() = %q, want %q", got, tt.want)
+				t.Errorf("convertElements() = %q, want %q", got, tt.want)
 			}
 		})
 	}
@@ -0,0 +1,158 @@
+package markdown
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/famasya/gdocs-cli/internal/document"
+	"google.golang.org/api/docs/v1"
+)
+
+// heading is a heading in the document body with its anchor.
+type heading struct {
+	level  int // 1-6, or 0 for the title and subtitle
+	text   string
+	number string // hierarchical number, if headings are numbered
+	id     string // Docs heading ID, e.g. h.abc123
+	anchor string
+
+	paragraph *docs.Paragraph
+}
+
+// headingLevels maps heading styles to their level in the outline.
+var headingLevels = map[string]int{
+	"HEADING_1": 1,
+	"HEADING_2": 2,
+	"HEADING_3": 3,
+	"HEADING_4": 4,
+	"HEADING_5": 5,
+	"HEADING_6": 6,
+}
+
+// collectHeadings returns the headings of the body in document order with
+// GitHub-style anchors, numbering duplicates like GitHub does. Headings are
+// numbered when hierarchical numbering is enabled, and the title and
+// subtitle are left out when they are not rendered as headings.
+func (c *Converter) collectHeadings() []heading {
+	if c.body == nil {
+		return nil
+	}
+
+	var headings []heading
+	used := make(map[string]int)
+	var outline document.Outline
+
+	for _, element := range c.body.Content {
+		paragraph := element.Paragraph
+		if paragraph == nil || !isHeadingStyle(paragraph.ParagraphStyle) {
+			continue
+		}
+		name := paragraph.ParagraphStyle.NamedStyleType
+		if name == "TITLE" && c.opts.DropTitle || name == "SUBTITLE" && c.opts.SubtitleEmphasis {
+			continue
+		}
+
+		text := strings.TrimSpace(strings.ReplaceAll(paragraphText(paragraph), "\v", " "))
+		if text == "" {
+			continue
+		}
+
+		level := headingLevels[name]
+		number := ""
+		if c.opts.NumberHeadings && level > 0 {
+			number = outline.Next(level)
+			text = number + " " + text
+		}
+
+		anchor := slugify(text)
+		if n, ok := used[anchor]; ok {
+			used[anchor] = n + 1
+			anchor = fmt.Sprintf("%s-%d", anchor, n+1)
+		} else {
+			used[anchor] = 0
+		}
+
+		headings = append(headings, heading{
+			level:  level,
+			text:   text,
+			number: number,
+			id:     paragraph.ParagraphStyle.HeadingId,
+			anchor: anchor,
+
+			paragraph: paragraph,
+		})
+	}
+
+	return headings
+}
+
+// documentHeadings returns the body's headings, collecting them on
+// first use.
+func (c *Converter) documentHeadings() []heading {
+	if c.headings == nil {
+		c.headings = c.collectHeadings()
+	}
+	return c.headings
+}
+
+// slugify creates a GitHub-style heading anchor: lowercase, with
+// punctuation removed and spaces replaced by hyphens.
+func slugify(text string) string {
+	var builder strings.Builder
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case unicode.IsLetter(r), unicode.IsDigit(r), r == '-', r == '_':
+			builder.WriteRune(r)
+		case r == ' ':
+			builder.WriteRune('-')
+		}
+	}
+	return builder.String()
+}
+
+// buildTableOfContents builds a table of contents from the document's
+// headings, or returns nil if it is dropped or there are no headings.
+func (c *Converter) buildTableOfContents() *document.TableOfContents {
+	if c.opts.TableOfContents == TOCDrop {
+		return nil
+	}
+
+	toc := &document.TableOfContents{}
+	for _, h := range c.documentHeadings() {
+		if h.level == 0 {
+			continue
+		}
+		toc.Entries = append(toc.Entries, document.TOCEntry{Level: h.level, Text: h.text, Anchor: h.anchor})
+	}
+	if len(toc.Entries) == 0 {
+		return nil
+	}
+	return toc
+}
+
+// renderTableOfContents renders a table of contents as a nested list of
+// links to the document's headings.
+func (r *Renderer) renderTableOfContents(toc *document.TableOfContents) string {
+	// Indent relative to the shallowest heading in the outline
+	minLevel := 0
+	for _, entry := range toc.Entries {
+		if minLevel == 0 || entry.Level < minLevel {
+			minLevel = entry.Level
+		}
+	}
+
+	var builder strings.Builder
+	for _, entry := range toc.Entries {
+		text := entry.Text
+		if !r.opts.RawText {
+			text = escapeText(text)
+		}
+		builder.WriteString(strings.Repeat("  ", entry.Level-minLevel))
+		builder.WriteString("- ")
+		builder.WriteString(r.link(text, "#"+entry.Anchor))
+		builder.WriteString("\n")
+	}
+	builder.WriteString("\n")
+	return builder.String()
+}
@@ -0,0 +1,95 @@
+package markdown
+
+import (
+	"testing"
+
+	"google.golang.org/api/docs/v1"
+)
+
+func headingParagraph(style, text string) *docs.StructuralElement {
+	return &docs.StructuralElement{
+		Paragraph: &docs.Paragraph{
+			Elements:       []*docs.ParagraphElement{{TextRun: &docs.TextRun{Content: text + "\n"}}},
+			ParagraphStyle: &docs.ParagraphStyle{NamedStyleType: style},
+		},
+	}
+}
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"Getting Started", "getting-started"},
+		{"What's new in v2.0?", "whats-new-in-v20"},
+		{"API_keys & secrets", "api_keys--secrets"},
+		{"Überblick", "überblick"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			if got := slugify(tt.text); got != tt.want {
+				t.Errorf("slugify(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvertTableOfContents(t *testing.T) {
+	doc := func() *docs.Document {
+		return &docs.Document{
+			Body: &docs.Body{Content: []*docs.StructuralElement{
+				headingParagraph("TITLE", "Design"),
+				{TableOfContents: &docs.TableOfContents{Content: []*docs.StructuralElement{
+					{Paragraph: &docs.Paragraph{Elements: []*docs.ParagraphElement{{TextRun: &docs.TextRun{Content: "Overview\n"}}}}},
+				}}},
+				headingParagraph("HEADING_1", "Overview"),
+				headingParagraph("HEADING_2", "Goals [draft]"),
+				headingParagraph("HEADING_1", "Details"),
+				headingParagraph("HEADING_2", "Goals [draft]"),
+			}},
+		}
+	}
+
+	t.Run("generate", func(t *testing.T) {
+		want := "# Design\n\n" +
+			"- [Overview](#overview)\n" +
+			"  - [Goals \\[draft\\]](#goals-draft)\n" +
+			"- [Details](#details)\n" +
+			"  - [Goals \\[draft\\]](#goals-draft-1)\n\n" +
+			"# Overview\n\n## Goals \\[draft\\]\n\n# Details\n\n## Goals \\[draft\\]\n\n"
+		if got := convertBody(t, NewConverter(doc())); got != want {
+			t.Errorf("convertBody() = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("drop", func(t *testing.T) {
+		c := NewConverter(doc())
+		c.SetOptions(Options{TableOfContents: TOCDrop})
+		want := "# Design\n\n# Overview\n\n## Goals \\[draft\\]\n\n# Details\n\n## Goals \\[draft\\]\n\n"
+		if got := convertBody(t, c); got != want {
+			t.Errorf("convertBody() = %q, want %q", got, want)
+		}
+	})
+}
+
+func TestCommonMarkHeadingAnchors(t *testing.T) {
+	goals := headingParagraph("HEADING_2", "Goals")
+	goals.Paragraph.ParagraphStyle.HeadingId = "h.goals"
+	doc := &docs.Document{Body: &docs.Body{Content: []*docs.StructuralElement{
+		{TableOfContents: &docs.TableOfContents{}},
+		headingParagraph("HEADING_1", "Overview"),
+		goals,
+		linkedParagraph("see", &docs.Link{HeadingId: "h.goals"}),
+	}}}
+
+	// CommonMark renderers don't derive heading IDs, so the links only
+	// work with the anchors written into the headings
+	c := NewConverter(doc)
+	c.SetOptions(Options{Flavor: FlavorCommonMark})
+	want := "- [Overview](#overview)\n  - [Goals](#goals)\n\n" +
+		"# <a id=\"overview\"></a>Overview\n\n## <a id=\"goals\"></a>Goals\n\n[see](#goals)\n\n"
+	if got := convertBody(t, c); got != want {
+		t.Errorf("convertBody() = %q, want %q", got, want)
+	}
+}